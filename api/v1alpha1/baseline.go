@@ -0,0 +1,125 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ory/oathkeeper-maester/internal/validation"
+	"sigs.k8s.io/yaml"
+)
+
+// BaselinePlacement decides where baseline rules are rendered relative to the Rules.
+type BaselinePlacement string
+
+const (
+	// BaselineFirst renders baseline rules before the Rules
+	BaselineFirst BaselinePlacement = "first"
+	// BaselineLast renders baseline rules after the Rules
+	BaselineLast BaselinePlacement = "last"
+)
+
+// Baseline holds static Oathkeeper rules that are rendered into every target along with the Rules.
+// Baseline rules keep their order and their IDs are reserved, Rules rendering to the same ID are left out.
+type Baseline struct {
+	Rules     []*RuleJSON
+	Placement BaselinePlacement
+}
+
+// ParseBaseline reads baseline rules from a JSON or YAML array of Oathkeeper rules. Rules without handlers get the
+// same defaults as Rules. Their upstream is defaulted when they are rendered, as it depends on the target, see
+// DecisionOnly.
+func ParseBaseline(raw []byte, placement BaselinePlacement) (*Baseline, error) {
+	data, err := yaml.YAMLToJSON(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []*RuleJSON
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, err
+	}
+
+	baseline := &Baseline{Placement: placement}
+	for _, rule := range rules {
+		defaulted := Rule{Spec: rule.RuleSpec}.ToRuleJSON()
+		defaulted.ID = rule.ID
+		defaulted.Upstream = rule.Upstream
+		baseline.Rules = append(baseline.Rules, defaulted)
+	}
+	return baseline, nil
+}
+
+// ValidateWith checks the placement, that every baseline rule has a unique ID and a match, and that only allowed handlers are used.
+func (b *Baseline) ValidateWith(config validation.Config) error {
+	switch b.Placement {
+	case BaselineFirst, BaselineLast:
+	default:
+		return fmt.Errorf("unknown baseline placement: %s", b.Placement)
+	}
+
+	seen := map[string]bool{}
+	for i, rule := range b.Rules {
+		if rule.ID == "" {
+			return fmt.Errorf("baseline rule #%d has no id", i+1)
+		}
+		if seen[rule.ID] {
+			return fmt.Errorf("baseline rule %s: id is used more than once", rule.ID)
+		}
+		seen[rule.ID] = true
+
+		if rule.Match == nil {
+			return fmt.Errorf("baseline rule %s: match is missing", rule.ID)
+		}
+		if err := (Rule{Spec: rule.RuleSpec}).ValidateWith(config); err != nil {
+			return fmt.Errorf("baseline rule %s: %w", rule.ID, err)
+		}
+	}
+	return nil
+}
+
+// DecisionOnly returns a copy of the baseline for a decision-only target, whose rules are rendered without upstream.
+// Baseline rules setting an upstream can't be rendered into such a target.
+func (b *Baseline) DecisionOnly() (*Baseline, error) {
+	if b == nil {
+		return nil, nil
+	}
+	decisionOnly := true
+	out := &Baseline{Placement: b.Placement, Rules: make([]*RuleJSON, 0, len(b.Rules))}
+	for _, rule := range b.Rules {
+		if rule.Upstream != nil {
+			return nil, fmt.Errorf("baseline rule %s: upstream can't be set on a rule rendered into a decision-only target", rule.ID)
+		}
+		r := *rule
+		r.DecisionOnly = &decisionOnly
+		out.Rules = append(out.Rules, &r)
+	}
+	return out, nil
+}
+
+// Reserves tells whether a baseline rule uses the ID. A nil Baseline reserves nothing.
+func (b *Baseline) Reserves(id string) bool {
+	if b == nil {
+		return false
+	}
+	for _, rule := range b.Rules {
+		if rule.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// IDs returns the IDs of the baseline rules. A nil Baseline has none.
+func (b *Baseline) IDs() []string {
+	if b == nil {
+		return nil
+	}
+	ids := make([]string, 0, len(b.Rules))
+	for _, rule := range b.Rules {
+		ids = append(ids, rule.ID)
+	}
+	return ids
+}
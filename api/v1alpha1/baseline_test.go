@@ -0,0 +1,199 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/ory/oathkeeper-maester/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const baselineYAML = `
+- id: health
+  match:
+    url: http://<.*>/health
+    methods: [GET]
+  authenticators:
+    - handler: anonymous
+  authorizer:
+    handler: allow
+- id: deny-admin
+  match:
+    url: http://<.*>/admin/<.*>
+    methods: [GET, POST]
+`
+
+func TestParseBaseline(t *testing.T) {
+
+	config := validation.Config{
+		AuthenticatorsAvailable: []string{"anonymous", "unauthorized"},
+		AuthorizersAvailable:    []string{"allow", "deny"},
+		MutatorsAvailable:       []string{"noop"},
+	}
+
+	t.Run("Should read YAML rules and apply the defaults of Rules", func(t *testing.T) {
+
+		//when
+		baseline, err := ParseBaseline([]byte(baselineYAML), BaselineLast)
+
+		//then
+		require.NoError(t, err)
+		require.NoError(t, baseline.ValidateWith(config))
+		assert.Equal(t, []string{"health", "deny-admin"}, baseline.IDs())
+		assert.Equal(t, "unauthorized", baseline.Rules[1].Authenticators[0].Name)
+		assert.Equal(t, "deny", baseline.Rules[1].Authorizer.Name)
+		assert.Nil(t, baseline.Rules[1].Upstream, "the upstream is defaulted when rendering")
+	})
+
+	t.Run("Should reject", func(t *testing.T) {
+
+		for name, raw := range map[string]string{
+			"rules without an id":     `[{"match": {"url": "http://a", "methods": ["GET"]}}]`,
+			"rules without a match":   `[{"id": "a"}]`,
+			"duplicate ids":           `[{"id": "a", "match": {"url": "http://a"}}, {"id": "a", "match": {"url": "http://b"}}]`,
+			"handlers not configured": `[{"id": "a", "match": {"url": "http://a"}, "authorizer": {"handler": "keto_engine_acp_ory"}}]`,
+		} {
+			t.Run(name, func(t *testing.T) {
+
+				//given
+				baseline, err := ParseBaseline([]byte(raw), BaselineLast)
+				require.NoError(t, err)
+
+				//then
+				assert.Error(t, baseline.ValidateWith(config))
+			})
+		}
+
+		t.Run("unknown placements", func(t *testing.T) {
+			baseline, err := ParseBaseline([]byte(`[]`), "middle")
+			require.NoError(t, err)
+			assert.Error(t, baseline.ValidateWith(config))
+		})
+	})
+}
+
+func TestToOathkeeperRulesWith(t *testing.T) {
+
+	rules := RuleList{Items: []Rule{*newStaticRuleNamed("a", nil), *newStaticRuleNamed("b", nil)}}
+
+	idsOf := func(t *testing.T, raw []byte) []string {
+		var actual []struct {
+			ID string `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal(raw, &actual))
+		var ids []string
+		for _, r := range actual {
+			ids = append(ids, r.ID)
+		}
+		return ids
+	}
+
+	t.Run("Should place baseline rules according to their placement", func(t *testing.T) {
+		for placement, expected := range map[BaselinePlacement][]string{
+			BaselineFirst: {"health", "deny-admin", "a.test", "b.test"},
+			BaselineLast:  {"a.test", "b.test", "health", "deny-admin"},
+		} {
+			//given
+			baseline, err := ParseBaseline([]byte(baselineYAML), placement)
+			require.NoError(t, err)
+
+			//when
+			raw, err := rules.ToOathkeeperRulesWith(baseline)
+
+			//then
+			require.NoError(t, err)
+			assert.Equal(t, expected, idsOf(t, raw), placement)
+		}
+	})
+
+	t.Run("Should leave out Rules with a reserved ID", func(t *testing.T) {
+
+		//given
+		baseline, err := ParseBaseline([]byte(`[{"id": "a.test", "match": {"url": "http://a"}}]`), BaselineLast)
+		require.NoError(t, err)
+
+		//when
+		raw, err := rules.ToOathkeeperRulesWith(baseline)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"b.test", "a.test"}, idsOf(t, raw))
+		assert.Contains(t, string(raw), "http://a")
+	})
+
+	t.Run("Should leave the shared baseline alone when rendering concurrently", func(t *testing.T) {
+
+		//given
+		baseline, err := ParseBaseline([]byte(`[{"id": "keto", "match": {"url": "http://a"}, "authorizer": {"handler": "keto_engine_acp_ory", "config": {"base_url": "http://keto"}}}]`), BaselineLast)
+		require.NoError(t, err)
+		config := validation.Config{AuthorizersAvailable: []string{"remote_json"}, Deprecated: validation.Deprecations, RewriteDeprecated: true}
+
+		//when
+		var wg sync.WaitGroup
+		rendered := make([][]byte, 8)
+		for i := range rendered {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				rendered[i], _ = rules.ToOathkeeperRulesFor(config, baseline)
+			}()
+		}
+		wg.Wait()
+
+		//then
+		assert.Equal(t, "keto_engine_acp_ory", baseline.Rules[0].Authorizer.Name)
+		for _, raw := range rendered {
+			assert.Contains(t, string(raw), `"handler": "remote_json"`)
+		}
+	})
+
+	t.Run("Should render baseline rules without upstream into decision-only targets only", func(t *testing.T) {
+
+		//given
+		baseline, err := ParseBaseline([]byte(baselineYAML), BaselineLast)
+		require.NoError(t, err)
+		decisionOnly, err := baseline.DecisionOnly()
+		require.NoError(t, err)
+
+		//when
+		proxied, err1 := RuleList{}.ToOathkeeperRulesWith(baseline)
+		decisions, err2 := RuleList{}.ToOathkeeperRulesWith(decisionOnly)
+
+		//then
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		assert.Contains(t, string(proxied), `"upstream"`)
+		assert.NotContains(t, string(decisions), `"upstream"`)
+		assert.Nil(t, baseline.Rules[0].DecisionOnly, "the baseline must not be changed")
+	})
+
+	t.Run("Should refuse baseline rules with an upstream in decision-only targets", func(t *testing.T) {
+
+		//given
+		baseline, err := ParseBaseline([]byte(`[{"id": "a", "match": {"url": "http://a"}, "upstream": {"url": "http://backend"}}]`), BaselineLast)
+		require.NoError(t, err)
+
+		//when
+		_, err = baseline.DecisionOnly()
+
+		//then
+		assert.EqualError(t, err, "baseline rule a: upstream can't be set on a rule rendered into a decision-only target")
+	})
+
+	t.Run("Should render like ToOathkeeperRules without a baseline", func(t *testing.T) {
+
+		//when
+		with, err1 := rules.ToOathkeeperRulesWith(nil)
+		without, err2 := rules.ToOathkeeperRules()
+
+		//then
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		assert.Equal(t, string(without), string(with))
+	})
+}
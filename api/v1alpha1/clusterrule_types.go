@@ -0,0 +1,49 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:validation:XValidation:rule="!has(self.spec) || !has(self.spec.configMapName)",message="ClusterRules always render into the default ConfigMap"
+// ClusterRule is a Rule owned by the platform rather than a namespace. It renders into the default target and its name is its ID.
+type ClusterRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RuleSpec   `json:"spec,omitempty"`
+	Status RuleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// ClusterRuleList contains a list of ClusterRule
+type ClusterRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterRule `json:"items"`
+}
+
+// ToRule returns the Rule the ClusterRule is rendered as. It has no namespace, so its ID is the name of the ClusterRule.
+func (cr ClusterRule) ToRule() Rule {
+	rule := Rule{ObjectMeta: cr.ObjectMeta, Spec: cr.Spec, Status: cr.Status}
+	rule.Namespace = ""
+	rule.Spec.ConfigMapName = nil
+	return rule
+}
+
+// ToRules returns the Rules the ClusterRules are rendered as
+func (crl ClusterRuleList) ToRules() RuleList {
+	rules := RuleList{Items: make([]Rule, 0, len(crl.Items))}
+	for _, cr := range crl.Items {
+		rules.Items = append(rules.Items, cr.ToRule())
+	}
+	return rules
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterRule{}, &ClusterRuleList{})
+}
@@ -0,0 +1,99 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	"github.com/ory/oathkeeper-maester/internal/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// rewriteHandler returns the handler rendered in place of the given one and, for deprecated handlers, a warning. The
+// replacement is only rendered with config.RewriteDeprecated, if it is available and the config can be translated.
+func rewriteHandler(kind string, h *Handler, config validation.Config) (*Handler, string) {
+	if h == nil || h.PresetRef != nil {
+		return h, ""
+	}
+	d := config.Deprecation(kind, h.Name)
+	switch {
+	case d == nil:
+		return h, ""
+	case d.Replacement == "":
+		return h, fmt.Sprintf("%s %s is deprecated", kind, h.Name)
+	case !config.RewriteDeprecated || d.Translate == nil:
+		return h, fmt.Sprintf("%s %s is deprecated, use %s instead", kind, h.Name, d.Replacement)
+	case !handlerAvailable(kind, d.Replacement, config):
+		return h, fmt.Sprintf("%s %s is deprecated and can't be rewritten, %s is not available", kind, h.Name, d.Replacement)
+	}
+
+	var raw []byte
+	if h.Config != nil {
+		raw = h.Config.Raw
+	}
+	translated, err := d.Translate(raw)
+	if err != nil {
+		return h, fmt.Sprintf("%s %s is deprecated and can't be rewritten to %s: %s", kind, h.Name, d.Replacement, err)
+	}
+	return &Handler{Name: d.Replacement, Config: &runtime.RawExtension{Raw: translated}},
+		fmt.Sprintf("%s %s is deprecated and rendered as %s", kind, h.Name, d.Replacement)
+}
+
+func handlerAvailable(kind, name string, config validation.Config) bool {
+	switch kind {
+	case validation.KindAuthenticator:
+		return config.IsAuthenticatorValid(name)
+	case validation.KindAuthorizer:
+		return config.IsAuthorizerValid(name)
+	case validation.KindMutator:
+		return config.IsMutatorValid(name)
+	default:
+		return config.IsErrorValid(name)
+	}
+}
+
+// rewriteDeprecated returns a copy of the spec with deprecated handlers replaced as rewriteHandler does, and the
+// warnings about them
+func (s RuleSpec) rewriteDeprecated(config validation.Config) (RuleSpec, []string) {
+	if len(config.Deprecated) == 0 {
+		return s, nil
+	}
+
+	var warnings []string
+	rewrite := func(kind string, h *Handler) *Handler {
+		rewritten, warning := rewriteHandler(kind, h, config)
+		if warning != "" {
+			warnings = append(warnings, warning)
+		}
+		return rewritten
+	}
+
+	out := *s.DeepCopy()
+	for _, a := range out.Authenticators {
+		if a != nil {
+			a.Handler = rewrite(validation.KindAuthenticator, a.Handler)
+		}
+	}
+	if out.Authorizer != nil {
+		out.Authorizer.Handler = rewrite(validation.KindAuthorizer, out.Authorizer.Handler)
+	}
+	for _, m := range out.Mutators {
+		if m != nil {
+			m.Handler = rewrite(validation.KindMutator, m.Handler)
+		}
+	}
+	for _, e := range out.Errors {
+		if e != nil {
+			e.Handler = rewrite(validation.KindError, e.Handler)
+		}
+	}
+	return out, warnings
+}
+
+// DeprecationWarnings reports the deprecated handlers of the Rule and whether they are rewritten. Handlers referencing
+// a preset are reported once it is resolved.
+func (r Rule) DeprecationWarnings(config validation.Config) []string {
+	_, warnings := r.Spec.rewriteDeprecated(config)
+	return warnings
+}
@@ -0,0 +1,62 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"sigs.k8s.io/yaml"
+)
+
+// OutputFormat is the format rendered rules are written in.
+type OutputFormat string
+
+const (
+	// FormatJSON writes rules as a JSON array, the zero value renders the same
+	FormatJSON OutputFormat = "json"
+	// FormatYAML writes rules as a YAML sequence
+	FormatYAML OutputFormat = "yaml"
+)
+
+// ParseOutputFormat returns the format of the given name, either json or yaml.
+func ParseOutputFormat(name string) (OutputFormat, error) {
+	switch f := OutputFormat(name); f {
+	case FormatJSON, FormatYAML:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unknown output format %q, use %q or %q", name, FormatJSON, FormatYAML)
+	}
+}
+
+// CheckFileName checks the extension of the file or ConfigMap key the rules are written to against the format.
+// Oathkeeper reads rule files ending in .yaml or .yml as YAML and every other file as JSON.
+func (f OutputFormat) CheckFileName(name string) error {
+	ext := filepath.Ext(name)
+	yamlExt := ext == ".yaml" || ext == ".yml"
+	switch {
+	case f == FormatYAML && !yamlExt:
+		return fmt.Errorf("%s doesn't end in .yaml or .yml, Oathkeeper would read the YAML rules as JSON", name)
+	case f != FormatYAML && yamlExt:
+		return fmt.Errorf("%s ends in %s, Oathkeeper would read the JSON rules as YAML", name, ext)
+	}
+	return nil
+}
+
+// Encode converts rules rendered by ToOathkeeperRules into the format. YAML keeps the order of the rules and sorts
+// the keys of every object, so equal rule sets still render to byte-identical output.
+func (f OutputFormat) Encode(rendered []byte) ([]byte, error) {
+	if f != FormatYAML {
+		return rendered, nil
+	}
+	return yaml.JSONToYAML(rendered)
+}
+
+// Decode converts rules written in the format back into JSON.
+func (f OutputFormat) Decode(written []byte) ([]byte, error) {
+	if f != FormatYAML {
+		return written, nil
+	}
+	return yaml.YAMLToJSON(written)
+}
@@ -0,0 +1,70 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputFormat(t *testing.T) {
+
+	t.Run("Should parse the known formats only", func(t *testing.T) {
+		format, err := ParseOutputFormat("yaml")
+		require.NoError(t, err)
+		assert.Equal(t, FormatYAML, format)
+
+		_, err = ParseOutputFormat("toml")
+		assert.EqualError(t, err, `unknown output format "toml", use "json" or "yaml"`)
+	})
+
+	t.Run("Should check the extension of the file name", func(t *testing.T) {
+		tests := map[string]struct {
+			format OutputFormat
+			name   string
+			valid  bool
+		}{
+			"json in a .json file":       {FormatJSON, "access-rules.json", true},
+			"json without an extension":  {FormatJSON, "rules", true},
+			"json in a .yaml file":       {FormatJSON, "access-rules.yaml", false},
+			"yaml in a .yaml file":       {FormatYAML, "access-rules.yaml", true},
+			"yaml in a .yml file":        {FormatYAML, "/etc/config/access-rules.yml", true},
+			"yaml in a .json file":       {FormatYAML, "access-rules.json", false},
+			"unset format in .yaml file": {"", "access-rules.yaml", false},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				err := test.format.CheckFileName(test.name)
+				assert.Equal(t, test.valid, err == nil, err)
+			})
+		}
+	})
+
+	t.Run("Should keep the rule order and escaping in YAML", func(t *testing.T) {
+
+		//given
+		rules := RuleList{Items: []Rule{
+			*newRule("r1", "test", "http://backend", "http://my-app/<.*>", nil, nil, nil, nil, nil, nil, nil),
+			*newRule("r2", "test", "http://backend", "http://my-app/a&b", nil, nil, nil, nil, nil, nil, nil),
+		}}
+		rendered, err := rules.ToOathkeeperRules()
+		require.NoError(t, err)
+
+		//when
+		encoded, err := FormatYAML.Encode(rendered)
+		require.NoError(t, err)
+		decoded, err := FormatYAML.Decode(encoded)
+		require.NoError(t, err)
+
+		//then
+		assert.Contains(t, string(encoded), "url: http://my-app/<.*>")
+		assert.Contains(t, string(encoded), "url: http://my-app/a&b")
+		assert.JSONEq(t, string(rendered), string(decoded))
+		again, err := FormatYAML.Encode(rendered)
+		require.NoError(t, err)
+		assert.Equal(t, string(encoded), string(again))
+	})
+}
@@ -0,0 +1,140 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// HandlerPresetKind is the kind of PresetRefs to a HandlerPreset in the namespace of the Rule
+	HandlerPresetKind = "HandlerPreset"
+	// ClusterHandlerPresetKind is the kind of PresetRefs to a ClusterHandlerPreset
+	ClusterHandlerPresetKind = "ClusterHandlerPreset"
+)
+
+// +kubebuilder:object:root=true
+// HandlerPreset holds a handler that Rules in its namespace reference by name instead of repeating it
+type HandlerPreset struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec HandlerPresetSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// HandlerPresetList contains a list of HandlerPreset
+type HandlerPresetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []HandlerPreset `json:"items"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// ClusterHandlerPreset holds a handler that Rules in any namespace reference by name instead of repeating it
+type ClusterHandlerPreset struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec HandlerPresetSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// ClusterHandlerPresetList contains a list of ClusterHandlerPreset
+type ClusterHandlerPresetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterHandlerPreset `json:"items"`
+}
+
+// HandlerPresetSpec defines the handler of a preset
+type HandlerPresetSpec struct {
+	// Name is the name of the handler
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"handler"`
+	// Config configures the handler. Configuration keys vary per handler.
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:XPreserveUnknownFields
+	// +optional
+	Config *runtime.RawExtension `json:"config,omitempty"`
+}
+
+// PresetRef references a HandlerPreset in the namespace of the Rule or a ClusterHandlerPreset
+type PresetRef struct {
+	// Name is the name of the preset
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Kind is the kind of the preset, HandlerPreset or ClusterHandlerPreset
+	// +kubebuilder:validation:Enum=HandlerPreset;ClusterHandlerPreset
+	// +optional
+	Kind string `json:"kind,omitempty"`
+}
+
+// IsCluster tells whether the reference points at a ClusterHandlerPreset
+func (p PresetRef) IsCluster() bool {
+	return p.Kind == ClusterHandlerPresetKind
+}
+
+func (p PresetRef) String() string {
+	if p.IsCluster() {
+		return ClusterHandlerPresetKind + "/" + p.Name
+	}
+	return HandlerPresetKind + "/" + p.Name
+}
+
+// WithPreset returns the handler of the preset. The config of h, if any, is laid over the config of the preset as a JSON merge patch.
+func (h *Handler) WithPreset(preset HandlerPresetSpec) (*Handler, error) {
+	resolved := &Handler{Name: preset.Name}
+	if preset.Config != nil {
+		resolved.Config = preset.Config.DeepCopy()
+	}
+	if h.Config == nil || len(h.Config.Raw) == 0 {
+		return resolved, nil
+	}
+
+	base := []byte("{}")
+	if resolved.Config != nil && len(resolved.Config.Raw) > 0 {
+		base = resolved.Config.Raw
+	}
+	merged, err := jsonpatch.MergePatch(base, h.Config.Raw)
+	if err != nil {
+		return nil, fmt.Errorf("config overlay of %s: %w", h.PresetRef, err)
+	}
+	resolved.Config = &runtime.RawExtension{Raw: merged}
+	return resolved, nil
+}
+
+// Handlers returns all handlers of the spec in the order authenticators, authorizer, mutators and errors
+func (s *RuleSpec) Handlers() []*Handler {
+	var handlers []*Handler
+	for _, a := range s.Authenticators {
+		if a != nil && a.Handler != nil {
+			handlers = append(handlers, a.Handler)
+		}
+	}
+	if s.Authorizer != nil && s.Authorizer.Handler != nil {
+		handlers = append(handlers, s.Authorizer.Handler)
+	}
+	for _, m := range s.Mutators {
+		if m != nil && m.Handler != nil {
+			handlers = append(handlers, m.Handler)
+		}
+	}
+	for _, e := range s.Errors {
+		if e != nil && e.Handler != nil {
+			handlers = append(handlers, e.Handler)
+		}
+	}
+	return handlers
+}
+
+func init() {
+	SchemeBuilder.Register(&HandlerPreset{}, &HandlerPresetList{}, &ClusterHandlerPreset{}, &ClusterHandlerPresetList{})
+}
@@ -3,8 +3,18 @@
 
 package v1alpha1
 
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+
+	"github.com/ory/oathkeeper-maester/internal/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
 // RuleJson is a representation of an Oathkeeper rule.
 type RuleJSON struct {
+	// ID is the ID of the rendered rule. It shadows RuleSpec.ID, which ToRuleJSON resolves into it.
 	ID       string `json:"id"`
 	RuleSpec `json:",inline"`
 }
@@ -14,19 +24,195 @@ func (rj RuleJSON) MarshalJSON() ([]byte, error) {
 
 	type Alias RuleJSON
 
+	var upstream *UpstreamJSON
+	if rj.Upstream != nil {
+		upstream = &UpstreamJSON{
+			URL:          rj.Upstream.URL,
+			PreserveHost: rj.Upstream.PreserveHost,
+			StripPath:    rj.Upstream.StripPath,
+		}
+	}
+
 	return unescapedMarshal(&struct {
 		Upstream *UpstreamJSON `json:"upstream,omitempty"`
+		// ID is repeated here to keep it ahead of the match
+		ID string `json:"id"`
+		// Match is either a URL with methods or a gRPC method
+		Match any `json:"match,omitempty"`
+		// Priority only orders the rendered rules, it shadows RuleSpec.Priority to keep it out of the output
+		Priority *int32 `json:"priority,omitempty"`
+		// OathkeeperClass only selects the instance of maester, it is kept out of the output as well
+		OathkeeperClass *string `json:"oathkeeperClass,omitempty"`
+		// Matches are rendered as rules of their own, they never appear in the output
+		Matches []*Match `json:"matches,omitempty"`
+		// DecisionOnly only leaves out the upstream
+		DecisionOnly *bool `json:"decisionOnly,omitempty"`
 		Alias
 	}{
-		Upstream: &UpstreamJSON{
-			URL:          rj.Upstream.URL,
-			PreserveHost: rj.Upstream.PreserveHost,
-			StripPath:    rj.Upstream.StripPath,
-		},
-		Alias: (Alias)(rj),
+		Upstream: upstream,
+		ID:       rj.ID,
+		Match:    rj.Match.toJSON(),
+		Alias:    (Alias)(rj),
+	})
+}
+
+// UnmarshalJSON is a custom unmarshal function that reads Oathkeeper rules into RuleJSON objects. It is the reverse of MarshalJSON.
+func (rj *RuleJSON) UnmarshalJSON(data []byte) error {
+
+	type Alias RuleJSON
+
+	aux := &struct {
+		Upstream *UpstreamJSON `json:"upstream,omitempty"`
+		Match    *MatchJSON    `json:"match,omitempty"`
+		*Alias
+	}{
+		Alias: (*Alias)(rj),
+	}
+
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+
+	if aux.Upstream != nil {
+		rj.Upstream = &Upstream{
+			URL:          aux.Upstream.URL,
+			PreserveHost: aux.Upstream.PreserveHost,
+			StripPath:    aux.Upstream.StripPath,
+		}
+	}
+
+	if aux.Match != nil {
+		rj.Match = &Match{URL: aux.Match.URL, Methods: aux.Match.Methods}
+		if aux.Match.Authority != "" || aux.Match.FullMethod != "" {
+			rj.Match = &Match{GRPC: &GRPCMatch{Authority: aux.Match.Authority, FullMethod: aux.Match.FullMethod}}
+		}
+	}
+
+	return nil
+}
+
+// trimTo leaves out the fields the profile doesn't read, the rule is a copy made by canonical
+func (rj *RuleJSON) trimTo(profile *validation.Profile) {
+	if !profile.HasField("upstream") {
+		rj.Upstream = nil
+	}
+	if !profile.HasField("errors") {
+		rj.Errors = nil
+	}
+}
+
+// canonical returns a copy of the rule with the configs of all handlers rewritten with sorted keys.
+func (rj *RuleJSON) canonical() (*RuleJSON, error) {
+	out := *rj
+
+	if rj.Authenticators != nil {
+		out.Authenticators = make([]*Authenticator, len(rj.Authenticators))
+		for i, a := range rj.Authenticators {
+			h, err := a.Handler.canonical()
+			if err != nil {
+				return nil, err
+			}
+			out.Authenticators[i] = &Authenticator{h}
+		}
+	}
+	if rj.Authorizer != nil {
+		h, err := rj.Authorizer.Handler.canonical()
+		if err != nil {
+			return nil, err
+		}
+		out.Authorizer = &Authorizer{h}
+	}
+	if rj.Mutators != nil {
+		out.Mutators = make([]*Mutator, len(rj.Mutators))
+		for i, m := range rj.Mutators {
+			h, err := m.Handler.canonical()
+			if err != nil {
+				return nil, err
+			}
+			out.Mutators[i] = &Mutator{h}
+		}
+	}
+	if rj.Errors != nil {
+		out.Errors = make([]*Error, len(rj.Errors))
+		for i, e := range rj.Errors {
+			h, err := e.Handler.canonical()
+			if err != nil {
+				return nil, err
+			}
+			out.Errors[i] = &Error{h}
+		}
+	}
+
+	return &out, nil
+}
+
+// canonical returns a copy of the handler whose config is re-encoded with sorted keys and without insignificant whitespace.
+func (h *Handler) canonical() (*Handler, error) {
+	if h == nil || h.Config == nil || len(h.Config.Raw) == 0 {
+		return h, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(h.Config.Raw))
+	// keep numbers as they are instead of round-tripping them through float64
+	dec.UseNumber()
+	var config interface{}
+	if err := dec.Decode(&config); err != nil {
+		return nil, err
+	}
+
+	raw, err := unescapedMarshal(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Handler{Name: h.Name, Config: &runtime.RawExtension{Raw: raw}}, nil
+}
+
+// sortRules sorts rules by descending priority first and by ID second.
+func sortRules(rules []*RuleJSON) {
+	sort.SliceStable(rules, func(i, j int) bool {
+		return renderedBefore(priorityOf(rules[i].RuleSpec), rules[i].ID, priorityOf(rules[j].RuleSpec), rules[j].ID)
 	})
 }
 
+func renderedBefore(pi int32, idi string, pj int32, idj string) bool {
+	if pi != pj {
+		return pi > pj
+	}
+	return idi < idj
+}
+
+func priorityOf(spec RuleSpec) int32 {
+	if spec.Priority == nil {
+		return 0
+	}
+	return *spec.Priority
+}
+
+// MatchJSON is a match as Oathkeeper reads it, of HTTP requests by URL and methods or of gRPC requests by authority
+// and full method
+type MatchJSON struct {
+	URL        string   `json:"url,omitempty"`
+	Methods    []string `json:"methods,omitempty"`
+	Authority  string   `json:"authority,omitempty"`
+	FullMethod string   `json:"full_method,omitempty"`
+}
+
+// toJSON returns the match in the shape Oathkeeper expects. HTTP matches always carry url and methods.
+func (m *Match) toJSON() any {
+	switch {
+	case m == nil:
+		return nil
+	case m.GRPC != nil:
+		return &MatchJSON{Authority: m.GRPC.Authority, FullMethod: m.GRPC.FullMethod}
+	default:
+		return &struct {
+			URL     string   `json:"url"`
+			Methods []string `json:"methods"`
+		}{m.URL, m.Methods}
+	}
+}
+
 // UpstreamJSON is a helper struct that representats Oathkeeper's upstream object.
 type UpstreamJSON struct {
 	URL          string  `json:"url"`
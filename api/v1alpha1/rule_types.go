@@ -5,6 +5,10 @@ package v1alpha1
 
 import (
 	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -16,6 +20,9 @@ const (
 	deny         = "deny"
 	noop         = "noop"
 	unauthorized = "unauthorized"
+	// matchIDSeparator joins the ID of a Rule and the index of one of its matches. Object names can't contain it, so
+	// the IDs never clash with the ones of other Rules.
+	matchIDSeparator = "#"
 )
 
 var (
@@ -44,11 +51,20 @@ type RuleList struct {
 }
 
 // RuleSpec defines the desired state of Rule
+// +kubebuilder:validation:XValidation:rule="has(self.match) != has(self.matches)",message="exactly one of match and matches must be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.decisionOnly) || !self.decisionOnly || !has(self.upstream)",message="upstream can't be set on a decision-only Rule"
 type RuleSpec struct {
 	// +kubebuilder:validation:Optional
 	// +optional
-	Upstream       *Upstream        `json:"upstream,omitempty"`
-	Match          *Match           `json:"match"`
+	Upstream *Upstream `json:"upstream,omitempty"`
+	// Match is the single match of the rule
+	// +optional
+	Match *Match `json:"match,omitempty"`
+	// Matches are rendered as one rule each, sharing everything else. Their IDs are the ID of the Rule suffixed with
+	// #<index>.
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	Matches        []*Match         `json:"matches,omitempty"`
 	Authenticators []*Authenticator `json:"authenticators,omitempty"`
 	Authorizer     *Authorizer      `json:"authorizer,omitempty"`
 	Mutators       []*Mutator       `json:"mutators,omitempty"`
@@ -59,6 +75,24 @@ type RuleSpec struct {
 	//
 	// ConfigMapName points to the K8s ConfigMap that contains these rules
 	ConfigMapName *string `json:"configMapName,omitempty"`
+	// Priority orders the rendered rules. Rules with a higher priority are rendered first, rules of equal priority are ordered by their ID.
+	// It is not part of the rendered Oathkeeper rule.
+	// +optional
+	Priority *int32 `json:"priority,omitempty"`
+	// OathkeeperClass selects the instance of maester, and with it the Oathkeeper deployment, the Rule is meant for.
+	// Rules without a class are handled by instances without one.
+	// +optional
+	OathkeeperClass *string `json:"oathkeeperClass,omitempty"`
+	// ID overrides the ID of the rendered Oathkeeper rule, which is derived from the name and namespace otherwise.
+	// It has to be unique within the target, the newer of two Rules with the same ID is not rendered.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	ID *string `json:"id,omitempty"`
+	// DecisionOnly renders the rule without upstream, for use with the decisions API of Oathkeeper only. Rules without
+	// it follow their target, and upstream can't be set on a decision-only Rule.
+	// +optional
+	DecisionOnly *bool `json:"decisionOnly,omitempty"`
 }
 
 // Validation defines the validation state of Rule
@@ -67,12 +101,45 @@ type Validation struct {
 	Valid *bool `json:"valid,omitempty"`
 	// +optional
 	Error *string `json:"validationError,omitempty"`
+	// Warnings report what is accepted but should be changed, such as deprecated handlers
+	// +optional
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Sync defines the state of writing the Rule into its target
+type Sync struct {
+	// +optional
+	Synced *bool `json:"synced,omitempty"`
+	// +optional
+	Reason *string `json:"reason,omitempty"`
+	// +optional
+	Error *string `json:"syncError,omitempty"`
 }
 
 // RuleStatus defines the observed state of Rule
 type RuleStatus struct {
 	// +optional
 	Validation *Validation `json:"validation,omitempty"`
+	// +optional
+	Sync *Sync `json:"sync,omitempty"`
+	// ClaimedBy identifies the instance of maester that handles the Rule
+	// +optional
+	ClaimedBy *string `json:"claimedBy,omitempty"`
+	// Matches holds the state of every match of a Rule with several matches
+	// +optional
+	// +listType=map
+	// +listMapKey=index
+	Matches []MatchStatus `json:"matches,omitempty"`
+}
+
+// MatchStatus defines the state of a single match of a Rule
+type MatchStatus struct {
+	// Index is the index of the match in spec.matches
+	Index int `json:"index"`
+	// ID is the ID of the rule rendered from the match
+	ID string `json:"id"`
+	// +optional
+	Validation *Validation `json:"validation,omitempty"`
 }
 
 // Upstream represents the location of a server where requests matching a rule should be forwarded to.
@@ -90,12 +157,28 @@ type Upstream struct {
 	PreserveHost *bool `json:"preserveHost,omitempty"`
 }
 
-// Match defines the URL(s) that an access rule should match.
+// Match defines the URL(s) that an access rule should match, or the gRPC method with grpc set.
+// +kubebuilder:validation:XValidation:rule="has(self.grpc) ? !has(self.url) && !has(self.methods) : has(self.url) && has(self.methods)",message="either url and methods or grpc must be set"
 type Match struct {
 	// URL is the URL that should be matched. It supports regex templates.
-	URL string `json:"url"`
+	// +optional
+	URL string `json:"url,omitempty"`
 	// Methods represent an array of HTTP methods (e.g. GET, POST, PUT, DELETE, ...)
-	Methods []string `json:"methods"`
+	// +optional
+	Methods []string `json:"methods,omitempty"`
+	// GRPC matches gRPC requests instead of HTTP ones. It needs Oathkeeper v0.40.0 or newer.
+	// +optional
+	GRPC *GRPCMatch `json:"grpc,omitempty"`
+}
+
+// GRPCMatch defines the gRPC method that an access rule should match.
+type GRPCMatch struct {
+	// Authority is the authority (host and port) of the request. It supports regex templates.
+	// +kubebuilder:validation:MinLength=1
+	Authority string `json:"authority"`
+	// FullMethod is the full name of the method, e.g. package.Service/Method. It supports regex templates.
+	// +kubebuilder:validation:MinLength=1
+	FullMethod string `json:"fullMethod"`
 }
 
 // Authenticator represents a handler that authenticates provided credentials.
@@ -119,9 +202,14 @@ type Error struct {
 }
 
 // Handler represents an Oathkeeper routine that operates on incoming requests. It is used to either validate a request (Authenticator, Authorizer) or modify it (Mutator).
+// +kubebuilder:validation:XValidation:rule="has(self.handler) != has(self.presetRef)",message="exactly one of handler and presetRef must be set"
 type Handler struct {
 	// Name is the name of a handler
-	Name string `json:"handler"`
+	// +optional
+	Name string `json:"handler,omitempty"`
+	// PresetRef takes the handler and its config from a preset instead. Config, if set, is laid over the config of the preset.
+	// +optional
+	PresetRef *PresetRef `json:"presetRef,omitempty"`
 	// Config configures the handler. Configuration keys vary per handler.
 	// +kubebuilder:validation:Type=object
 	// +kubebuilder:pruning:PreserveUnknownFields
@@ -130,17 +218,77 @@ type Handler struct {
 }
 
 // ToOathkeeperRules transforms a RuleList object into a JSON object digestible by Oathkeeper.
+// The output is deterministic: rules are sorted by descending priority and then by ID, and keys of handler configs are sorted,
+// so equal rule sets always render to byte-identical JSON.
 func (rl RuleList) ToOathkeeperRules() ([]byte, error) {
+	return rl.ToOathkeeperRulesWith(nil)
+}
+
+// ToOathkeeperRulesWith renders the Rules like ToOathkeeperRules along with the baseline rules, which are placed before
+// or after them in their original order. Rules rendering to an ID reserved by the baseline are left out.
+func (rl RuleList) ToOathkeeperRulesWith(baseline *Baseline) ([]byte, error) {
+	return rl.ToOathkeeperRulesFor(validation.Config{}, baseline)
+}
+
+// ToOathkeeperRulesFor renders the Rules like ToOathkeeperRulesWith for the Oathkeeper the config describes: fields
+// its profile doesn't read are left out and deprecated handlers are rewritten if the config asks for it. An empty config
+// keeps all fields and handlers.
+func (rl RuleList) ToOathkeeperRulesFor(config validation.Config, baseline *Baseline) ([]byte, error) {
 
-	rules := make([]*RuleJSON, len(rl.Items))
+	rules := make([]*RuleJSON, 0, len(rl.Items))
 
 	for i := range rl.Items {
-		rules[i] = rl.Items[i].ToRuleJSON()
+		for _, ruleJSON := range rl.Items[i].ToRuleJSONs() {
+			if baseline.Reserves(ruleJSON.ID) {
+				continue
+			}
+			ruleJSON.RuleSpec, _ = ruleJSON.RuleSpec.rewriteDeprecated(config)
+			ruleJSON, err := ruleJSON.canonical()
+			if err != nil {
+				return nil, fmt.Errorf("rule %s/%s: %w", rl.Items[i].Namespace, rl.Items[i].Name, err)
+			}
+			ruleJSON.trimTo(config.Profile)
+			rules = append(rules, ruleJSON)
+		}
+	}
+
+	sortRules(rules)
+
+	if baseline != nil && len(baseline.Rules) > 0 {
+		base := make([]*RuleJSON, 0, len(baseline.Rules))
+		for _, rule := range baseline.Rules {
+			// the baseline is shared by every render, so only a copy of it is defaulted and rewritten
+			r := Rule{Spec: rule.RuleSpec}.ToRuleJSON()
+			r.ID = rule.ID
+			r.RuleSpec, _ = r.RuleSpec.rewriteDeprecated(config)
+			ruleJSON, err := r.canonical()
+			if err != nil {
+				return nil, fmt.Errorf("baseline rule %s: %w", rule.ID, err)
+			}
+			ruleJSON.trimTo(config.Profile)
+			base = append(base, ruleJSON)
+		}
+		if baseline.Placement == BaselineFirst {
+			rules = append(base, rules...)
+		} else {
+			rules = append(rules, base...)
+		}
 	}
 
 	return unescapedMarshalIndent(rules, "", "  ")
 }
 
+// Sorted returns a copy of the list with the Rules in the order ToOathkeeperRules renders them.
+func (rl RuleList) Sorted() RuleList {
+	rlCopy := rl
+	rlCopy.Items = append([]Rule(nil), rl.Items...)
+	sort.SliceStable(rlCopy.Items, func(i, j int) bool {
+		ri, rj := rlCopy.Items[i], rlCopy.Items[j]
+		return renderedBefore(priorityOf(ri.Spec), ri.ToRuleJSON().ID, priorityOf(rj.Spec), rj.ToRuleJSON().ID)
+	})
+	return rlCopy
+}
+
 // FilterNotValid filters out Rules which doesn't pass validation due to being not processed yet or due to negative result of validation. It returns a list of Rules which passed validation successfully.
 func (rl RuleList) FilterNotValid() RuleList {
 	rlCopy := rl
@@ -163,7 +311,7 @@ func (rl RuleList) FilterConfigMapName(name *string) RuleList {
 			if name == nil {
 				validRules = append(validRules, rule)
 			}
-		} else if *rule.Spec.ConfigMapName == *name {
+		} else if name != nil && *rule.Spec.ConfigMapName == *name {
 			validRules = append(validRules, rule)
 		}
 	}
@@ -184,20 +332,61 @@ func (rl RuleList) FilterOutRule(r Rule) RuleList {
 	return rlCopy
 }
 
-// ValidateWith uses provided validation configuration to check whether the rule have proper handlers set. Nil is a valid handler.
+// FilterDeleted filters out Rules which are being deleted and only wait for their finalizers to run.
+func (rl RuleList) FilterDeleted() RuleList {
+	rlCopy := rl
+	validRules := []Rule{}
+	for _, rule := range rl.Items {
+		if rule.ObjectMeta.DeletionTimestamp.IsZero() {
+			validRules = append(validRules, rule)
+		}
+	}
+	rlCopy.Items = validRules
+	return rlCopy
+}
+
+// ValidateWith uses provided validation configuration to check whether the rule have proper handlers set. Nil is a valid handler. Handlers referencing a preset are checked once it is resolved.
 func (r Rule) ValidateWith(config validation.Config) error {
 
+	// the handlers that are rendered are checked, which for rewritten deprecated ones are their replacements
+	r.Spec, _ = r.Spec.rewriteDeprecated(config)
+
+	var invalidMatches []string
+	for i, err := range r.matchErrors() {
+		if err != nil {
+			invalidMatches = append(invalidMatches, fmt.Sprintf("matches[%d]: %s", i, err))
+		}
+	}
+	if len(invalidMatches) != 0 {
+		return fmt.Errorf("invalid matches: %s", strings.Join(invalidMatches, ", "))
+	}
+
+	if r.Spec.IsDecisionOnly() && r.Spec.Upstream != nil {
+		return fmt.Errorf("upstream can't be set on a decision-only rule")
+	}
+
+	if r.Spec.Errors != nil && !config.Profile.HasField("errors") {
+		return fmt.Errorf("error handlers need a newer Oathkeeper, please check the configured Oathkeeper version")
+	}
+
+	if !config.SupportsGRPCMatch() && r.hasGRPCMatch() {
+		return fmt.Errorf("gRPC matches need Oathkeeper %s or newer, please check the configured Oathkeeper version", validation.GRPCMatchVersion)
+	}
+
 	var invalidHandlers []string
 
 	if r.Spec.Authenticators != nil {
 		for _, authenticator := range r.Spec.Authenticators {
+			if authenticator.PresetRef != nil {
+				continue
+			}
 			if valid := config.IsAuthenticatorValid(authenticator.Name); !valid {
 				invalidHandlers = append(invalidHandlers, fmt.Sprintf("authenticator/%s", authenticator.Name))
 			}
 		}
 	}
 
-	if r.Spec.Authorizer != nil {
+	if r.Spec.Authorizer != nil && r.Spec.Authorizer.PresetRef == nil {
 		if valid := config.IsAuthorizerValid(r.Spec.Authorizer.Name); !valid {
 			invalidHandlers = append(invalidHandlers, fmt.Sprintf("authorizer/%s", r.Spec.Authorizer.Name))
 		}
@@ -205,12 +394,26 @@ func (r Rule) ValidateWith(config validation.Config) error {
 
 	if r.Spec.Mutators != nil {
 		for _, m := range r.Spec.Mutators {
+			if m.PresetRef != nil {
+				continue
+			}
 			if valid := config.IsMutatorValid(m.Name); !valid {
 				invalidHandlers = append(invalidHandlers, m.Name)
 			}
 		}
 	}
 
+	if r.Spec.Errors != nil {
+		for _, e := range r.Spec.Errors {
+			if e.PresetRef != nil {
+				continue
+			}
+			if valid := config.IsErrorValid(e.Name); !valid {
+				invalidHandlers = append(invalidHandlers, fmt.Sprintf("error/%s", e.Name))
+			}
+		}
+	}
+
 	if len(invalidHandlers) != 0 {
 		return fmt.Errorf("invalid handlers: %s, please check the configuration", invalidHandlers)
 	}
@@ -222,9 +425,12 @@ func (r Rule) ValidateWith(config validation.Config) error {
 func (r Rule) ToRuleJSON() *RuleJSON {
 
 	ruleJSON := &RuleJSON{
-		ID:       r.Name + "." + r.Namespace,
+		ID:       RuleID(r.Name, r.Namespace),
 		RuleSpec: r.Spec,
 	}
+	if r.Spec.ID != nil {
+		ruleJSON.ID = *r.Spec.ID
+	}
 
 	if ruleJSON.Authenticators == nil {
 		ruleJSON.Authenticators = []*Authenticator{{unauthorizedHandler}}
@@ -236,6 +442,11 @@ func (r Rule) ToRuleJSON() *RuleJSON {
 		ruleJSON.Mutators = []*Mutator{{noopHandler}}
 	}
 
+	if r.Spec.IsDecisionOnly() {
+		ruleJSON.Upstream = nil
+		return ruleJSON
+	}
+
 	if ruleJSON.Upstream == nil {
 		ruleJSON.Upstream = &Upstream{}
 	}
@@ -247,6 +458,147 @@ func (r Rule) ToRuleJSON() *RuleJSON {
 	return ruleJSON
 }
 
+// ToRuleJSONs transforms a Rule object into the RuleJSON objects it is rendered as, one per match
+func (r Rule) ToRuleJSONs() []*RuleJSON {
+	ruleJSON := r.ToRuleJSON()
+	if len(r.Spec.Matches) == 0 {
+		return []*RuleJSON{ruleJSON}
+	}
+
+	ruleJSONs := make([]*RuleJSON, 0, len(r.Spec.Matches))
+	for i, match := range r.Spec.Matches {
+		matchJSON := *ruleJSON
+		matchJSON.ID = MatchID(ruleJSON.ID, i)
+		matchJSON.Match = match
+		matchJSON.Matches = nil
+		ruleJSONs = append(ruleJSONs, &matchJSON)
+	}
+	return ruleJSONs
+}
+
+// IDs returns the IDs of the Oathkeeper rules rendered from the Rule
+func (r Rule) IDs() []string {
+	var ids []string
+	for _, ruleJSON := range r.ToRuleJSONs() {
+		ids = append(ids, ruleJSON.ID)
+	}
+	return ids
+}
+
+// MatchStatuses reports the ID and validation of every match of a Rule with several matches, nil for other Rules
+func (r Rule) MatchStatuses() []MatchStatus {
+	if len(r.Spec.Matches) == 0 {
+		return nil
+	}
+
+	id := r.ToRuleJSON().ID
+	statuses := make([]MatchStatus, 0, len(r.Spec.Matches))
+	for i, err := range r.matchErrors() {
+		valid := err == nil
+		status := MatchStatus{Index: i, ID: MatchID(id, i), Validation: &Validation{Valid: &valid}}
+		if err != nil {
+			msg := err.Error()
+			status.Validation.Error = &msg
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// matchErrors returns the problem of every match in spec.matches, nil for the ones without. A match is invalid if it
+// matches a request another match of the Rule does as well, Oathkeeper rejects requests matching several rules.
+func (r Rule) matchErrors() []error {
+	errs := make([]error, len(r.Spec.Matches))
+	for i, match := range r.Spec.Matches {
+		switch {
+		case match == nil:
+			errs[i] = fmt.Errorf("url is required")
+		case match.GRPC != nil:
+			switch {
+			case match.URL != "" || len(match.Methods) != 0:
+				errs[i] = fmt.Errorf("url and methods can't be combined with grpc")
+			case match.GRPC.Authority == "":
+				errs[i] = fmt.Errorf("grpc.authority is required")
+			case match.GRPC.FullMethod == "":
+				errs[i] = fmt.Errorf("grpc.fullMethod is required")
+			}
+		case match.URL == "":
+			errs[i] = fmt.Errorf("url is required")
+		case len(match.Methods) == 0:
+			errs[i] = fmt.Errorf("methods are required")
+		}
+		if errs[i] != nil {
+			continue
+		}
+		for j, other := range r.Spec.Matches[:i] {
+			if other != nil && match.overlaps(other) {
+				errs[i] = fmt.Errorf("overlaps with matches[%d]", j)
+				break
+			}
+		}
+	}
+	return errs
+}
+
+// IsDecisionOnly tells whether the rule is rendered without upstream
+func (s RuleSpec) IsDecisionOnly() bool {
+	return s.DecisionOnly != nil && *s.DecisionOnly
+}
+
+// hasGRPCMatch tells whether the Rule matches gRPC requests
+func (r Rule) hasGRPCMatch() bool {
+	if r.Spec.Match != nil && r.Spec.Match.GRPC != nil {
+		return true
+	}
+	return slices.ContainsFunc(r.Spec.Matches, func(m *Match) bool { return m != nil && m.GRPC != nil })
+}
+
+// overlaps tells whether both matches match the same requests
+func (m *Match) overlaps(other *Match) bool {
+	if (m.GRPC == nil) != (other.GRPC == nil) {
+		return false
+	}
+	if m.GRPC != nil {
+		return *m.GRPC == *other.GRPC
+	}
+	return other.URL == m.URL && slicesOverlap(other.Methods, m.Methods)
+}
+
+func slicesOverlap(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if strings.EqualFold(x, y) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// MatchID returns the ID of the Oathkeeper rule rendered from the match at index of a Rule with the given ID
+func MatchID(id string, index int) string {
+	return id + matchIDSeparator + strconv.Itoa(index)
+}
+
+// RuleID returns the ID of the Oathkeeper rule rendered from the Rule with the given name and namespace.
+// ClusterRules have no namespace, their ID is their name.
+func RuleID(name, namespace string) string {
+	if namespace == "" {
+		return name
+	}
+	return name + "." + namespace
+}
+
+// ParseRuleID splits an Oathkeeper rule ID generated by RuleID back into the name and namespace of the Rule.
+// Namespaces can't contain dots, so everything after the last one is the namespace. It returns false if id has no dot.
+func ParseRuleID(id string) (name, namespace string, ok bool) {
+	i := strings.LastIndex(id, ".")
+	if i <= 0 || i == len(id)-1 {
+		return "", "", false
+	}
+	return id[:i], id[i+1:], true
+}
+
 func init() {
 	SchemeBuilder.Register(&Rule{}, &RuleList{})
 }
@@ -5,6 +5,7 @@ package v1alpha1
 
 import (
 	_ "embed"
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -114,6 +115,100 @@ func TestToOathkeeperRules(t *testing.T) {
 	})
 }
 
+func TestToOathkeeperRulesDeterminism(t *testing.T) {
+
+	t.Run("Should order rules by descending priority and then by ID", func(t *testing.T) {
+
+		//given
+		rules := []Rule{
+			*newStaticRuleNamed("b", nil),
+			*newStaticRuleNamed("c", newInt32Ptr(10)),
+			*newStaticRuleNamed("a", nil),
+			*newStaticRuleNamed("d", newInt32Ptr(-1)),
+		}
+		class := "edge"
+		rules[0].Spec.OathkeeperClass = &class
+
+		//when
+		raw, err := RuleList{Items: rules}.ToOathkeeperRules()
+
+		//then
+		require.NoError(t, err)
+		var actual []map[string]interface{}
+		require.NoError(t, json.Unmarshal(raw, &actual))
+		var ids []string
+		for _, r := range actual {
+			ids = append(ids, r["id"].(string))
+			assert.NotContains(t, r, "priority")
+			assert.NotContains(t, r, "oathkeeperClass")
+		}
+		assert.Equal(t, []string{"c.test", "a.test", "b.test", "d.test"}, ids)
+	})
+
+	t.Run("Should render equal handler configs identically", func(t *testing.T) {
+
+		//given
+		r1 := newStaticRule(nil, nil, []*Mutator{{newHandler("header", `{"b": 12345678901234567890, "a": {"y": "<1>", "x": 2}}`)}}, nil)
+		r2 := newStaticRule(nil, nil, []*Mutator{{newHandler("header", `{"a":{"x":2,"y":"<1>"},"b":12345678901234567890}`)}}, nil)
+
+		//when
+		raw1, err1 := RuleList{Items: []Rule{*r1}}.ToOathkeeperRules()
+		raw2, err2 := RuleList{Items: []Rule{*r2}}.ToOathkeeperRules()
+
+		//then
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		assert.Equal(t, string(raw1), string(raw2))
+		assert.Contains(t, string(raw1), "12345678901234567890")
+		assert.Contains(t, string(raw1), `"<1>"`)
+		assert.Equal(t, `{"b": 12345678901234567890, "a": {"y": "<1>", "x": 2}}`, string(r1.Spec.Mutators[0].Config.Raw))
+	})
+}
+
+func TestRuleJSONUnmarshal(t *testing.T) {
+
+	t.Run("Should read rendered Oathkeeper rules back without losing information", func(t *testing.T) {
+
+		//given
+		var rules []*RuleJSON
+
+		//when
+		err := json.Unmarshal([]byte(template), &rules)
+		require.NoError(t, err)
+		raw, err := unescapedMarshalIndent(rules, "", "  ")
+
+		//then
+		require.NoError(t, err)
+		require.Len(t, rules, 4)
+		assert.Equal(t, "/api/v1", *rules[0].Upstream.StripPath)
+		assert.True(t, *rules[0].Upstream.PreserveHost)
+		assert.Equal(t, template, string(raw))
+	})
+}
+
+func TestParseRuleID(t *testing.T) {
+
+	for id, tc := range map[string]struct {
+		name      string
+		namespace string
+		ok        bool
+	}{
+		"foo.default":        {"foo", "default", true},
+		"foo.bar.default":    {"foo.bar", "default", true},
+		"foo":                {"", "", false},
+		".default":           {"", "", false},
+		"foo.":               {"", "", false},
+		RuleID("r1", "test"): {"r1", "test", true},
+	} {
+		t.Run(id, func(t *testing.T) {
+			name, namespace, ok := ParseRuleID(id)
+			assert.Equal(t, tc.name, name)
+			assert.Equal(t, tc.namespace, namespace)
+			assert.Equal(t, tc.ok, ok)
+		})
+	}
+}
+
 func TestToRuleJson(t *testing.T) {
 
 	assert := assert.New(t)
@@ -256,6 +351,364 @@ func TestValidateWith(t *testing.T) {
 	})
 }
 
+func TestMatches(t *testing.T) {
+
+	newRuleWithMatches := func(matches ...*Match) Rule {
+		rule := newRule("r1", "test", "http://backend", "", nil, nil, nil, nil, nil, nil, nil)
+		rule.Spec.Match = nil
+		rule.Spec.Matches = matches
+		return *rule
+	}
+
+	t.Run("Should render one rule per match with suffixed IDs", func(t *testing.T) {
+
+		//given
+		rule := newRuleWithMatches(
+			&Match{URL: "http://my-app/api/v1/<**>", Methods: []string{"GET"}},
+			&Match{URL: "http://my-app/api/v2/<**>", Methods: []string{"GET"}},
+		)
+
+		//when
+		raw, err := RuleList{Items: []Rule{rule}}.ToOathkeeperRules()
+
+		//then
+		require.NoError(t, err)
+		var rendered []map[string]interface{}
+		require.NoError(t, json.Unmarshal(raw, &rendered))
+		require.Len(t, rendered, 2)
+		assert.Equal(t, "r1.test#0", rendered[0]["id"])
+		assert.Equal(t, "http://my-app/api/v1/<**>", rendered[0]["match"].(map[string]interface{})["url"])
+		assert.Equal(t, "r1.test#1", rendered[1]["id"])
+		assert.NotContains(t, rendered[0], "matches")
+		assert.Equal(t, []string{"r1.test#0", "r1.test#1"}, rule.IDs())
+	})
+
+	t.Run("Should keep the ID of Rules with a single match", func(t *testing.T) {
+
+		//given
+		rule := newRule("r1", "test", "http://backend", "http://my-app", nil, nil, nil, nil, nil, nil, nil)
+
+		//when
+		ids := rule.IDs()
+
+		//then
+		assert.Equal(t, []string{"r1.test"}, ids)
+		assert.Nil(t, rule.MatchStatuses())
+	})
+
+	t.Run("Should report invalid matches by index", func(t *testing.T) {
+
+		//given
+		rule := newRuleWithMatches(
+			&Match{URL: "http://my-app/<**>", Methods: []string{"GET", "POST"}},
+			&Match{URL: "http://my-app/<**>", Methods: []string{"DELETE"}},
+			&Match{URL: "http://my-app/<**>", Methods: []string{"post"}},
+			&Match{URL: "http://my-app/other"},
+		)
+
+		//when
+		err := rule.ValidateWith(validation.Config{})
+		statuses := rule.MatchStatuses()
+
+		//then
+		require.EqualError(t, err, "invalid matches: matches[2]: overlaps with matches[0], matches[3]: methods are required")
+		require.Len(t, statuses, 4)
+		assert.Equal(t, "r1.test#1", statuses[1].ID)
+		assert.True(t, *statuses[1].Validation.Valid)
+		assert.False(t, *statuses[2].Validation.Valid)
+		assert.Equal(t, "overlaps with matches[0]", *statuses[2].Validation.Error)
+	})
+}
+
+func TestGRPCMatch(t *testing.T) {
+
+	newGRPCRule := func() Rule {
+		rule := newRule("r1", "test", "http://backend", "", nil, nil, nil, nil, nil, nil, nil)
+		rule.Spec.Match = &Match{GRPC: &GRPCMatch{Authority: "my-app:9000", FullMethod: "my.app.Service/<.*>"}}
+		return *rule
+	}
+	v40, err := validation.ProfileFor(validation.Version{Minor: 40, Patch: 1})
+	require.NoError(t, err)
+	v39, err := validation.ProfileFor(validation.Version{Minor: 39})
+	require.NoError(t, err)
+
+	t.Run("Should render the authority and full method", func(t *testing.T) {
+
+		//given
+		rule := newGRPCRule()
+
+		//when
+		raw, err := RuleList{Items: []Rule{rule}}.ToOathkeeperRules()
+
+		//then
+		require.NoError(t, err)
+		var rendered []map[string]interface{}
+		require.NoError(t, json.Unmarshal(raw, &rendered))
+		require.Len(t, rendered, 1)
+		assert.Equal(t, map[string]interface{}{"authority": "my-app:9000", "full_method": "my.app.Service/<.*>"}, rendered[0]["match"])
+	})
+
+	t.Run("Should read gRPC matches back", func(t *testing.T) {
+
+		//given
+		var ruleJSON RuleJSON
+
+		//when
+		err := json.Unmarshal([]byte(`{"id":"r1","match":{"authority":"my-app:9000","full_method":"my.app.Service/Get"}}`), &ruleJSON)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, &Match{GRPC: &GRPCMatch{Authority: "my-app:9000", FullMethod: "my.app.Service/Get"}}, ruleJSON.Match)
+	})
+
+	t.Run("Should only accept gRPC matches for Oathkeeper versions supporting them", func(t *testing.T) {
+		tests := map[string]struct {
+			profile *validation.Profile
+			valid   bool
+		}{
+			"unknown version": {nil, false},
+			"older version":   {v39, false},
+			"newer version":   {v40, true},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+
+				//when
+				err := newGRPCRule().ValidateWith(validation.Config{Profile: test.profile})
+
+				//then
+				if test.valid {
+					assert.NoError(t, err)
+				} else {
+					assert.EqualError(t, err, "gRPC matches need Oathkeeper v0.40.0 or newer, please check the configured Oathkeeper version")
+				}
+			})
+		}
+	})
+
+	t.Run("Should report overlapping gRPC matches", func(t *testing.T) {
+
+		//given
+		rule := newGRPCRule()
+		rule.Spec.Matches = []*Match{
+			rule.Spec.Match,
+			{URL: "http://my-app:9000/<**>", Methods: []string{"POST"}},
+			{GRPC: &GRPCMatch{Authority: "my-app:9000", FullMethod: "my.app.Service/<.*>"}},
+			{URL: "http://my-app/", GRPC: &GRPCMatch{Authority: "my-app:9000", FullMethod: "my.app.Other/Get"}},
+		}
+		rule.Spec.Match = nil
+
+		//when
+		err = rule.ValidateWith(validation.Config{Profile: v40})
+
+		//then
+		assert.EqualError(t, err, "invalid matches: matches[2]: overlaps with matches[0], matches[3]: url and methods can't be combined with grpc")
+	})
+}
+
+func TestProfiles(t *testing.T) {
+
+	v34, err := validation.ProfileFor(validation.Version{Minor: 34, Patch: 2})
+	require.NoError(t, err)
+	newRuleWithErrors := func() Rule {
+		return *newRule("r1", "test", "http://backend", "http://my-app", nil, nil, nil, nil, nil, nil, []*Error{{&Handler{Name: "json"}}})
+	}
+
+	t.Run("Should leave out the fields the release doesn't read", func(t *testing.T) {
+
+		//given
+		rules := RuleList{Items: []Rule{newRuleWithErrors()}}
+
+		//when
+		trimmed, err := rules.ToOathkeeperRulesFor(validation.Config{Profile: v34}, nil)
+		require.NoError(t, err)
+		full, err := rules.ToOathkeeperRulesFor(validation.Config{}, nil)
+		require.NoError(t, err)
+
+		//then
+		assert.NotContains(t, string(trimmed), `"errors"`)
+		assert.Contains(t, string(trimmed), `"upstream"`)
+		assert.Contains(t, string(full), `"errors"`)
+	})
+
+	t.Run("Should reject fields the release doesn't read", func(t *testing.T) {
+
+		//when
+		err := newRuleWithErrors().ValidateWith(validation.Config{Profile: v34})
+
+		//then
+		assert.EqualError(t, err, "error handlers need a newer Oathkeeper, please check the configured Oathkeeper version")
+	})
+}
+
+func TestErrorHandlersFromOathkeeperConfig(t *testing.T) {
+
+	//given
+	enabled, err := validation.EnabledHandlers([]byte(`
+authenticators:
+  noop:
+    enabled: true
+authorizers:
+  allow:
+    enabled: true
+mutators:
+  noop:
+    enabled: true
+errors:
+  handlers:
+    json:
+      enabled: false
+    redirect:
+      enabled: true
+`))
+	require.NoError(t, err)
+	config := validation.Config{
+		AuthenticatorsAvailable: enabled.Authenticators,
+		AuthorizersAvailable:    enabled.Authorizers,
+		MutatorsAvailable:       enabled.Mutators,
+		ErrorsAvailable:         enabled.Errors,
+	}
+	newRuleWithErrors := func(handlers ...string) Rule {
+		var errs []*Error
+		for _, h := range handlers {
+			errs = append(errs, &Error{&Handler{Name: h}})
+		}
+		return *newRule("r1", "test", "http://backend", "http://my-app", nil, nil, nil, nil, nil, nil, errs)
+	}
+
+	t.Run("Should accept enabled error handlers", func(t *testing.T) {
+		assert.NoError(t, newRuleWithErrors("redirect").ValidateWith(config))
+	})
+
+	t.Run("Should reject disabled and unknown error handlers", func(t *testing.T) {
+		err := newRuleWithErrors("json", "redirect", "teapot").ValidateWith(config)
+		assert.EqualError(t, err, "invalid handlers: [error/json error/teapot], please check the configuration")
+	})
+
+	t.Run("Should skip error handlers referencing a preset", func(t *testing.T) {
+		rule := newRuleWithErrors("redirect")
+		rule.Spec.Errors = append(rule.Spec.Errors, &Error{&Handler{PresetRef: &PresetRef{Name: "errors"}}})
+		assert.NoError(t, rule.ValidateWith(config))
+	})
+}
+
+func TestDeprecatedHandlers(t *testing.T) {
+
+	config := validation.Config{
+		AuthenticatorsAvailable: []string{"noop"},
+		AuthorizersAvailable:    []string{"keto_engine_acp_ory", "remote_json"},
+		MutatorsAvailable:       []string{"noop"},
+		Deprecated:              validation.Deprecations,
+	}
+	newKetoRule := func(ketoConfig string) Rule {
+		authorizer := &Authorizer{&Handler{Name: "keto_engine_acp_ory", Config: &runtime.RawExtension{Raw: []byte(ketoConfig)}}}
+		return *newRule("r1", "test", "http://backend", "http://my-app", nil, nil, nil, nil, authorizer, nil, nil)
+	}
+
+	t.Run("Should warn about deprecated handlers without rewriting them", func(t *testing.T) {
+
+		//given
+		rule := newKetoRule(`{"base_url": "http://keto", "required_action": "get", "required_resource": "blog"}`)
+
+		//when
+		warnings := rule.DeprecationWarnings(config)
+		rendered, err := RuleList{Items: []Rule{rule}}.ToOathkeeperRulesFor(config, nil)
+		require.NoError(t, err)
+
+		//then
+		assert.Equal(t, []string{"authorizer keto_engine_acp_ory is deprecated, use remote_json instead"}, warnings)
+		assert.Contains(t, string(rendered), `"handler": "keto_engine_acp_ory"`)
+		assert.NoError(t, rule.ValidateWith(config))
+	})
+
+	t.Run("Should render the replacement in rewrite mode", func(t *testing.T) {
+
+		//given
+		rewriting := config
+		rewriting.RewriteDeprecated = true
+		rule := newKetoRule(`{"base_url": "http://keto", "required_action": "get", "required_resource": "blog"}`)
+
+		//when
+		warnings := rule.DeprecationWarnings(rewriting)
+		rendered, err := RuleList{Items: []Rule{rule}}.ToOathkeeperRulesFor(rewriting, nil)
+		require.NoError(t, err)
+
+		//then
+		assert.Equal(t, []string{"authorizer keto_engine_acp_ory is deprecated and rendered as remote_json"}, warnings)
+		assert.Contains(t, string(rendered), `"handler": "remote_json"`)
+		assert.Contains(t, string(rendered), `"remote": "http://keto/engines/acp/ory/regex/allowed"`)
+		assert.Equal(t, "keto_engine_acp_ory", rule.Spec.Authorizer.Name)
+	})
+
+	t.Run("Should keep handlers that can't be rewritten", func(t *testing.T) {
+
+		//given
+		rewriting := config
+		rewriting.RewriteDeprecated = true
+		unavailable := rewriting
+		unavailable.AuthorizersAvailable = []string{"keto_engine_acp_ory"}
+
+		//when
+		untranslatable := newKetoRule(`{"required_action": "get", "required_resource": "blog"}`).DeprecationWarnings(rewriting)
+		notAvailable := newKetoRule(`{"base_url": "http://keto"}`).DeprecationWarnings(unavailable)
+
+		//then
+		assert.Equal(t, []string{"authorizer keto_engine_acp_ory is deprecated and can't be rewritten to remote_json: base_url is not part of the config"}, untranslatable)
+		assert.Equal(t, []string{"authorizer keto_engine_acp_ory is deprecated and can't be rewritten, remote_json is not available"}, notAvailable)
+	})
+}
+
+func TestDecisionOnly(t *testing.T) {
+
+	decisionOnly := true
+
+	t.Run("Should render decision-only rules without upstream", func(t *testing.T) {
+
+		//given
+		rule := newRule("r1", "test", "", "http://my-app", nil, nil, nil, nil, nil, nil, nil)
+		rule.Spec.Upstream = nil
+		rule.Spec.DecisionOnly = &decisionOnly
+
+		//when
+		raw, err := RuleList{Items: []Rule{*rule}}.ToOathkeeperRules()
+
+		//then
+		require.NoError(t, err)
+		var rendered []map[string]interface{}
+		require.NoError(t, json.Unmarshal(raw, &rendered))
+		require.Len(t, rendered, 1)
+		assert.NotContains(t, rendered[0], "upstream")
+		assert.NotContains(t, rendered[0], "decisionOnly")
+		assert.NoError(t, rule.ValidateWith(validation.Config{}))
+	})
+
+	t.Run("Should reject decision-only rules with upstream", func(t *testing.T) {
+
+		//given
+		rule := newRule("r1", "test", "http://backend", "http://my-app", nil, nil, nil, nil, nil, nil, nil)
+		rule.Spec.DecisionOnly = &decisionOnly
+
+		//when
+		err := rule.ValidateWith(validation.Config{})
+
+		//then
+		assert.EqualError(t, err, "upstream can't be set on a decision-only rule")
+	})
+
+	t.Run("Should not default the upstream of decision-only rules", func(t *testing.T) {
+
+		//given
+		spec := RuleSpec{DecisionOnly: &decisionOnly}
+		defaults := &RuleDefaultsSpec{Upstream: &UpstreamDefaults{PreserveHost: &decisionOnly}}
+
+		//when
+		defaults.ApplyTo(&spec)
+
+		//then
+		assert.Nil(t, spec.Upstream)
+	})
+}
+
 func TestFilterNotValid(t *testing.T) {
 
 	t.Run("Should return only valid rules", func(t *testing.T) {
@@ -282,6 +735,12 @@ func newStaticRule(authenticators []*Authenticator, authorizer *Authorizer, muta
 	return newRule("r1", "test", "", "", newStringPtr(""), nil, newBoolPtr(false), authenticators, authorizer, mutators, errors)
 }
 
+func newStaticRuleNamed(name string, priority *int32) *Rule {
+	r := newRule(name, "test", "", "", nil, nil, nil, nil, nil, nil, nil)
+	r.Spec.Priority = priority
+	return r
+}
+
 func newRule(name, namespace, upstreamURL, matchURL string, stripURLPath, configMapName *string, preserveURLHost *bool, authenticators []*Authenticator, authorizer *Authorizer, mutators []*Mutator, errors []*Error) *Rule {
 
 	spec := RuleSpec{
@@ -339,6 +798,10 @@ func newBoolPtr(b bool) *bool {
 	return &b
 }
 
+func newInt32Ptr(i int32) *int32 {
+	return &i
+}
+
 func newStringPtr(s string) *string {
 	return &s
 }
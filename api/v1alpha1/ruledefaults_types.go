@@ -0,0 +1,111 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DefaultsName is the name of the RuleDefaults of a namespace and of the ClusterRuleDefaults, objects with other names are rejected
+const DefaultsName = "default"
+
+// +kubebuilder:object:root=true
+// +kubebuilder:validation:XValidation:rule="self.metadata.name == 'default'",message="RuleDefaults must be named default"
+// RuleDefaults holds the handlers and upstream settings of Rules in its namespace that omit them
+type RuleDefaults struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec RuleDefaultsSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// RuleDefaultsList contains a list of RuleDefaults
+type RuleDefaultsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RuleDefaults `json:"items"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:validation:XValidation:rule="self.metadata.name == 'default'",message="ClusterRuleDefaults must be named default"
+// ClusterRuleDefaults holds the handlers and upstream settings of Rules that omit them and have no RuleDefaults in their namespace setting them
+type ClusterRuleDefaults struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec RuleDefaultsSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// ClusterRuleDefaultsList contains a list of ClusterRuleDefaults
+type ClusterRuleDefaultsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterRuleDefaults `json:"items"`
+}
+
+// RuleDefaultsSpec defines the defaults of Rules. Every field is defaulted on its own, unset fields fall back to the next level.
+type RuleDefaultsSpec struct {
+	// +optional
+	Authenticators []*Authenticator `json:"authenticators,omitempty"`
+	// +optional
+	Authorizer *Authorizer `json:"authorizer,omitempty"`
+	// +optional
+	Mutators []*Mutator `json:"mutators,omitempty"`
+	// +optional
+	Errors []*Error `json:"errors,omitempty"`
+	// +optional
+	Upstream *UpstreamDefaults `json:"upstream,omitempty"`
+}
+
+// UpstreamDefaults defines the defaults of the upstream of Rules. The URL is specific to every Rule and has no default.
+type UpstreamDefaults struct {
+	// StripPath replaces the provided path prefix when forwarding the requested URL to the upstream URL.
+	// +optional
+	StripPath *string `json:"stripPath,omitempty"`
+	// PreserveHost includes the host and port of the url value if set to false. If true, the host and port of the ORY Oathkeeper Proxy will be used instead.
+	// +optional
+	PreserveHost *bool `json:"preserveHost,omitempty"`
+}
+
+// ApplyTo fills the fields the spec omits from the defaults. Handlers of the defaults are copied, so the spec can be changed safely.
+// A nil RuleDefaultsSpec changes nothing, and decision-only specs get no upstream.
+func (d *RuleDefaultsSpec) ApplyTo(spec *RuleSpec) {
+	if d == nil {
+		return
+	}
+	d = d.DeepCopy()
+
+	if spec.Authenticators == nil {
+		spec.Authenticators = d.Authenticators
+	}
+	if spec.Authorizer == nil {
+		spec.Authorizer = d.Authorizer
+	}
+	if spec.Mutators == nil {
+		spec.Mutators = d.Mutators
+	}
+	if spec.Errors == nil {
+		spec.Errors = d.Errors
+	}
+
+	if d.Upstream == nil || spec.IsDecisionOnly() {
+		return
+	}
+	if spec.Upstream == nil {
+		spec.Upstream = &Upstream{}
+	}
+	if spec.Upstream.StripPath == nil {
+		spec.Upstream.StripPath = d.Upstream.StripPath
+	}
+	if spec.Upstream.PreserveHost == nil {
+		spec.Upstream.PreserveHost = d.Upstream.PreserveHost
+	}
+}
+
+func init() {
+	SchemeBuilder.Register(&RuleDefaults{}, &RuleDefaultsList{}, &ClusterRuleDefaults{}, &ClusterRuleDefaultsList{})
+}
@@ -0,0 +1,198 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// routeSeparator joins the names of a RuleSet and a route. Object names can't contain it, so the Rules of routes
+// never clash with Rules.
+const routeSeparator = ":"
+
+// +kubebuilder:object:root=true
+// RuleSet bundles the routes of a service that share handlers and upstream. Every route is rendered as its own rule.
+type RuleSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RuleSetSpec   `json:"spec,omitempty"`
+	Status RuleSetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// RuleSetList contains a list of RuleSet
+type RuleSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RuleSet `json:"items"`
+}
+
+// RuleSetSpec defines the routes of a RuleSet and what they share
+type RuleSetSpec struct {
+	// Upstream is the upstream of routes that don't set their own
+	// +optional
+	Upstream *Upstream `json:"upstream,omitempty"`
+	// Authenticators are the authenticators of routes that don't set their own
+	// +optional
+	Authenticators []*Authenticator `json:"authenticators,omitempty"`
+	// Authorizer is the authorizer of routes that don't set their own
+	// +optional
+	Authorizer *Authorizer `json:"authorizer,omitempty"`
+	// Mutators are the mutators of routes that don't set their own
+	// +optional
+	Mutators []*Mutator `json:"mutators,omitempty"`
+	// Errors are the error handlers of routes that don't set their own
+	// +optional
+	Errors []*Error `json:"errors,omitempty"`
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*
+	//
+	// ConfigMapName points to the K8s ConfigMap that contains the rules of all routes
+	ConfigMapName *string `json:"configMapName,omitempty"`
+	// Priority is the priority of routes that don't set their own, see RuleSpec
+	// +optional
+	Priority *int32 `json:"priority,omitempty"`
+	// OathkeeperClass selects the instance of maester the RuleSet is meant for, see RuleSpec
+	// +optional
+	OathkeeperClass *string `json:"oathkeeperClass,omitempty"`
+	// DecisionOnly renders all routes without upstream, see RuleSpec
+	// +optional
+	DecisionOnly *bool `json:"decisionOnly,omitempty"`
+	// Routes are rendered as one rule each, with the ID <RuleSet>:<route>.<namespace>
+	// +kubebuilder:validation:MinItems=1
+	// +listType=map
+	// +listMapKey=name
+	Routes []Route `json:"routes"`
+}
+
+// Route is a single route of a RuleSet. Fields that are set replace the ones shared by the RuleSet.
+type Route struct {
+	// Name identifies the route within the RuleSet and makes up the ID of its rule
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name  string `json:"name"`
+	Match *Match `json:"match"`
+	// +optional
+	Upstream *Upstream `json:"upstream,omitempty"`
+	// +optional
+	Authenticators []*Authenticator `json:"authenticators,omitempty"`
+	// +optional
+	Authorizer *Authorizer `json:"authorizer,omitempty"`
+	// +optional
+	Mutators []*Mutator `json:"mutators,omitempty"`
+	// +optional
+	Errors []*Error `json:"errors,omitempty"`
+	// +optional
+	Priority *int32 `json:"priority,omitempty"`
+}
+
+// RuleSetStatus defines the observed state of RuleSet
+type RuleSetStatus struct {
+	// Routes holds the state of every route
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Routes []RouteStatus `json:"routes,omitempty"`
+	// ClaimedBy identifies the instance of maester that handles the RuleSet
+	// +optional
+	ClaimedBy *string `json:"claimedBy,omitempty"`
+}
+
+// RouteStatus defines the observed state of a route
+type RouteStatus struct {
+	Name string `json:"name"`
+	// +optional
+	Validation *Validation `json:"validation,omitempty"`
+	// +optional
+	Sync *Sync `json:"sync,omitempty"`
+}
+
+// RouteRuleName returns the name of the Rule a route is rendered as
+func RouteRuleName(ruleSet, route string) string {
+	return ruleSet + routeSeparator + route
+}
+
+// ParseRouteRuleName splits the name of a Rule returned by RouteRuleName into the names of the RuleSet and the route.
+// It returns false for the names of Rules.
+func ParseRouteRuleName(name string) (ruleSet, route string, ok bool) {
+	return strings.Cut(name, routeSeparator)
+}
+
+// ToRules returns the Rules the routes are rendered as. They carry the metadata of the RuleSet, the status of their
+// route and the handlers and upstream of the RuleSet unless the route sets its own.
+func (rs RuleSet) ToRules() RuleList {
+	rsCopy := rs.DeepCopy()
+	rules := RuleList{Items: make([]Rule, 0, len(rsCopy.Spec.Routes))}
+	for _, route := range rsCopy.Spec.Routes {
+		rule := Rule{
+			ObjectMeta: rsCopy.ObjectMeta,
+			Spec: RuleSpec{
+				Upstream:        rsCopy.Spec.Upstream,
+				Match:           route.Match,
+				Authenticators:  rsCopy.Spec.Authenticators,
+				Authorizer:      rsCopy.Spec.Authorizer,
+				Mutators:        rsCopy.Spec.Mutators,
+				Errors:          rsCopy.Spec.Errors,
+				ConfigMapName:   rsCopy.Spec.ConfigMapName,
+				Priority:        rsCopy.Spec.Priority,
+				OathkeeperClass: rsCopy.Spec.OathkeeperClass,
+				DecisionOnly:    rsCopy.Spec.DecisionOnly,
+			},
+			Status: RuleStatus{ClaimedBy: rsCopy.Status.ClaimedBy},
+		}
+		rule.Name = RouteRuleName(rs.Name, route.Name)
+		if route.Upstream != nil {
+			rule.Spec.Upstream = route.Upstream
+		}
+		if route.Authenticators != nil {
+			rule.Spec.Authenticators = route.Authenticators
+		}
+		if route.Authorizer != nil {
+			rule.Spec.Authorizer = route.Authorizer
+		}
+		if route.Mutators != nil {
+			rule.Spec.Mutators = route.Mutators
+		}
+		if route.Errors != nil {
+			rule.Spec.Errors = route.Errors
+		}
+		if route.Priority != nil {
+			rule.Spec.Priority = route.Priority
+		}
+		if status := rsCopy.Status.Route(route.Name); status != nil {
+			rule.Status.Validation = status.Validation
+			rule.Status.Sync = status.Sync
+		}
+		rules.Items = append(rules.Items, rule)
+	}
+	return rules
+}
+
+// ToRules returns the Rules the routes of all RuleSets are rendered as
+func (rsl RuleSetList) ToRules() RuleList {
+	rules := RuleList{Items: []Rule{}}
+	for _, rs := range rsl.Items {
+		rules.Items = append(rules.Items, rs.ToRules().Items...)
+	}
+	return rules
+}
+
+// Route returns the status of the named route, or nil if it has none yet
+func (s *RuleSetStatus) Route(name string) *RouteStatus {
+	for i := range s.Routes {
+		if s.Routes[i].Name == name {
+			return &s.Routes[i]
+		}
+	}
+	return nil
+}
+
+func init() {
+	SchemeBuilder.Register(&RuleSet{}, &RuleSetList{})
+}
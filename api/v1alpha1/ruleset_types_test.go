@@ -0,0 +1,74 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRuleSetToRules(t *testing.T) {
+
+	//given
+	ruleSet := RuleSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "team-a", UID: "uid"},
+		Spec: RuleSetSpec{
+			Upstream:       &Upstream{URL: "http://api.team-a"},
+			Authenticators: []*Authenticator{{&Handler{Name: "jwt"}}},
+			Authorizer:     &Authorizer{&Handler{Name: "allow"}},
+			ConfigMapName:  newStringPtr("api-rules"),
+			Routes: []Route{
+				{Name: "list", Match: &Match{URL: "http://api/items", Methods: []string{"GET"}}},
+				{
+					Name:           "health",
+					Match:          &Match{URL: "http://api/health", Methods: []string{"GET"}},
+					Authenticators: []*Authenticator{{&Handler{Name: "anonymous"}}},
+				},
+			},
+		},
+		Status: RuleSetStatus{Routes: []RouteStatus{{Name: "health", Validation: &Validation{Valid: newBoolPtr(true)}}}},
+	}
+
+	//when
+	rules := ruleSet.ToRules()
+
+	//then
+	require.Len(t, rules.Items, 2)
+	list, health := rules.Items[0], rules.Items[1]
+	assert.Equal(t, "api:list.team-a", list.ToRuleJSON().ID)
+	assert.Equal(t, "api:health.team-a", health.ToRuleJSON().ID)
+	assert.Equal(t, "jwt", list.Spec.Authenticators[0].Name)
+	assert.Equal(t, "anonymous", health.Spec.Authenticators[0].Name)
+	assert.Equal(t, "allow", health.Spec.Authorizer.Name)
+	assert.Equal(t, "http://api.team-a", health.Spec.Upstream.URL)
+	assert.Equal(t, "api-rules", *list.Spec.ConfigMapName)
+	assert.Equal(t, ruleSet.UID, list.UID)
+	assert.Nil(t, list.Status.Validation)
+	assert.True(t, *health.Status.Validation.Valid)
+
+	list.Spec.Authenticators[0].Name = "changed"
+	assert.Equal(t, "jwt", ruleSet.Spec.Authenticators[0].Name, "the RuleSet must not be changed")
+}
+
+func TestParseRouteRuleName(t *testing.T) {
+	for name, expected := range map[string]struct {
+		ruleSet, route string
+		ok             bool
+	}{
+		RouteRuleName("api", "health"): {"api", "health", true},
+		"r1":                           {"r1", "", false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ruleSet, route, ok := ParseRouteRuleName(name)
+			assert.Equal(t, expected.ok, ok)
+			if ok {
+				assert.Equal(t, expected.ruleSet, ruleSet)
+				assert.Equal(t, expected.route, route)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+func TestConfigMapOperatorBaseline(t *testing.T) {
+
+	ctx := context.Background()
+	rules := oathkeeperv1alpha1.RuleList{Items: []oathkeeperv1alpha1.Rule{
+		newValidRule("r1", "a"),
+		newValidRule("r2", "b"),
+	}}
+
+	newBaseline := func(t *testing.T, placement oathkeeperv1alpha1.BaselinePlacement) *oathkeeperv1alpha1.Baseline {
+		baseline, err := oathkeeperv1alpha1.ParseBaseline([]byte(`[{"id": "health", "match": {"url": "http://<.*>/health"}}]`), placement)
+		require.NoError(t, err)
+		return baseline
+	}
+
+	t.Run("should render baseline rules into the target", func(t *testing.T) {
+
+		//given
+		reconciler, _ := newConfigMapReconciler(t)
+		operator := reconciler.ConfigMapOperator
+		operator.Baseline = newBaseline(t, oathkeeperv1alpha1.BaselineFirst)
+
+		//when
+		require.NoError(t, operator.CreateOrUpdate(ctx, rules, nil))
+
+		//then
+		var actual apiv1.ConfigMap
+		require.NoError(t, operator.Get(ctx, defaultConfigMap, &actual))
+		assert.Equal(t, []string{"health", "r1.a", "r2.b"}, idsOf(t, actual.Data["access-rules.json"]))
+	})
+
+	t.Run("should render baseline rules into a single shard", func(t *testing.T) {
+
+		//given
+		reconciler, _ := newConfigMapReconciler(t)
+		operator := reconciler.ConfigMapOperator
+		operator.Sharding = Sharding{Strategy: ShardingNamespace}
+		operator.Baseline = newBaseline(t, oathkeeperv1alpha1.BaselineLast)
+
+		//when
+		require.NoError(t, operator.CreateOrUpdate(ctx, rules, nil))
+
+		//then
+		var actual apiv1.ConfigMap
+		require.NoError(t, operator.Get(ctx, defaultConfigMap, &actual))
+		assert.Equal(t, []string{"r1.a"}, idsOf(t, actual.Data["access-rules-a.json"]))
+		assert.Equal(t, []string{"r2.b", "health"}, idsOf(t, actual.Data["access-rules-b.json"]))
+	})
+
+	t.Run("should render baseline rules without any Rules", func(t *testing.T) {
+
+		//given
+		reconciler, _ := newConfigMapReconciler(t)
+		operator := reconciler.ConfigMapOperator
+		operator.Sharding = Sharding{Strategy: ShardingNamespace}
+		operator.Baseline = newBaseline(t, oathkeeperv1alpha1.BaselineLast)
+
+		//when
+		require.NoError(t, operator.CreateOrUpdate(ctx, noRules, nil))
+
+		//then
+		var actual apiv1.ConfigMap
+		require.NoError(t, operator.Get(ctx, defaultConfigMap, &actual))
+		assert.Equal(t, []string{"health"}, idsOf(t, actual.Data["access-rules-baseline.json"]))
+	})
+
+	t.Run("should not take baseline rules for foreign ones in merge mode", func(t *testing.T) {
+
+		//given
+		configMap := newManagedConfigMap(defaultConfigMap, `[{"id": "health"}, {"id": "hand-made"}]`)
+		configMap.Data[managedRuleIDsKey("access-rules.json")] = ""
+		reconciler, _ := newConfigMapReconciler(t, configMap)
+		operator := reconciler.ConfigMapOperator
+		operator.Merge = true
+		operator.Baseline = newBaseline(t, oathkeeperv1alpha1.BaselineLast)
+
+		//when
+		require.NoError(t, operator.CreateOrUpdate(ctx, rules, nil))
+
+		//then
+		var actual apiv1.ConfigMap
+		require.NoError(t, operator.Get(ctx, defaultConfigMap, &actual))
+		assert.Equal(t, []string{"r1.a", "r2.b", "health", "hand-made"}, idsOf(t, actual.Data["access-rules.json"]))
+		assert.Equal(t, "health\nr1.a\nr2.b", actual.Data[managedRuleIDsKey("access-rules.json")])
+	})
+}
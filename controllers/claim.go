@@ -0,0 +1,167 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	apiv1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Claim decides which Rules an instance of maester is responsible for. An instance only reconciles, validates, finalizes
+// and renders the Rules it claims. The zero value claims every Rule without an oathkeeperClass.
+type Claim struct {
+	// Selector the labels of a Rule have to match, nil matches every Rule
+	Selector labels.Selector
+	// Class the spec.oathkeeperClass of a Rule has to equal, Rules without a class are claimed by instances without one
+	Class string
+	// NamespaceSelector the labels of the namespace of a Rule have to match, nil matches every namespace.
+	// Namespaces are looked up on every reconcile, so labelling a namespace claims its Rules right away.
+	NamespaceSelector labels.Selector
+}
+
+// Matches tells whether the Rule is claimed
+func (c Claim) Matches(rule *oathkeeperv1alpha1.Rule) bool {
+	if c.Selector != nil && !c.Selector.Matches(labels.Set(rule.Labels)) {
+		return false
+	}
+	class := ""
+	if rule.Spec.OathkeeperClass != nil {
+		class = *rule.Spec.OathkeeperClass
+	}
+	return class == c.Class
+}
+
+// MatchesRuleSet tells whether the RuleSet, and with it all of its routes, is claimed
+func (c Claim) MatchesRuleSet(ruleSet *oathkeeperv1alpha1.RuleSet) bool {
+	return c.Matches(&oathkeeperv1alpha1.Rule{
+		ObjectMeta: ruleSet.ObjectMeta,
+		Spec:       oathkeeperv1alpha1.RuleSpec{OathkeeperClass: ruleSet.Spec.OathkeeperClass},
+	})
+}
+
+// Filter returns a list of the claimed Rules
+func (c Claim) Filter(rl oathkeeperv1alpha1.RuleList) oathkeeperv1alpha1.RuleList {
+	rlCopy := rl
+	rlCopy.Items = []oathkeeperv1alpha1.Rule{}
+	for _, rule := range rl.Items {
+		if c.Matches(&rule) {
+			rlCopy.Items = append(rlCopy.Items, rule)
+		}
+	}
+	return rlCopy
+}
+
+// namespaceClaimed tells whether Rules in the namespace are claimed according to NamespaceSelector
+func (c Claim) namespaceClaimed(ctx context.Context, reader client.Reader, namespace string) (bool, error) {
+	if c.NamespaceSelector == nil {
+		return true, nil
+	}
+	var ns apiv1.Namespace
+	if err := reader.Get(ctx, types.NamespacedName{Name: namespace}, &ns); err != nil {
+		if apierrs.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return c.NamespaceSelector.Matches(labels.Set(ns.Labels)), nil
+}
+
+// FilterNamespaces returns a list of the Rules in namespaces matching NamespaceSelector
+func (c Claim) FilterNamespaces(ctx context.Context, reader client.Reader, rl oathkeeperv1alpha1.RuleList) (oathkeeperv1alpha1.RuleList, error) {
+	if c.NamespaceSelector == nil {
+		return rl, nil
+	}
+
+	var namespaces apiv1.NamespaceList
+	if err := reader.List(ctx, &namespaces, client.MatchingLabelsSelector{Selector: c.NamespaceSelector}); err != nil {
+		return rl, err
+	}
+	claimed := map[string]bool{}
+	for _, ns := range namespaces.Items {
+		claimed[ns.Name] = true
+	}
+
+	rlCopy := rl
+	rlCopy.Items = []oathkeeperv1alpha1.Rule{}
+	for _, rule := range rl.Items {
+		if claimed[rule.Namespace] {
+			rlCopy.Items = append(rlCopy.Items, rule)
+		}
+	}
+	return rlCopy, nil
+}
+
+// String identifies the claim in the status of claimed Rules
+func (c Claim) String() string {
+	selector := ""
+	if c.Selector != nil {
+		selector = c.Selector.String()
+	}
+	namespaceSelector := ""
+	if c.NamespaceSelector != nil {
+		namespaceSelector = c.NamespaceSelector.String()
+	}
+	return fmt.Sprintf("class=%q,selector=%q,namespaceSelector=%q", c.Class, selector, namespaceSelector)
+}
+
+// predicate passes events of claimed Rules, ClusterRules and RuleSets, and updates of ones that were claimed before, so they are rendered out of the target
+func (c Claim) predicate() predicate.Predicate {
+	matches := func(o client.Object) bool {
+		switch rule := o.(type) {
+		case *oathkeeperv1alpha1.Rule:
+			return c.Matches(rule)
+		case *oathkeeperv1alpha1.ClusterRule:
+			converted := rule.ToRule()
+			return c.Matches(&converted)
+		case *oathkeeperv1alpha1.RuleSet:
+			return c.MatchesRuleSet(rule)
+		default:
+			return false
+		}
+	}
+	return predicate.Funcs{
+		CreateFunc:  func(e event.CreateEvent) bool { return matches(e.Object) },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return matches(e.Object) },
+		GenericFunc: func(e event.GenericEvent) bool { return matches(e.Object) },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return matches(e.ObjectOld) || matches(e.ObjectNew)
+		},
+	}
+}
+
+// namespaceHandler enqueues all Rules of a namespace whose labels changed, so they are claimed or rendered out of their target
+func (c Claim) namespaceHandler(reader client.Reader) (handler.EventHandler, predicate.Predicate) {
+	enqueue := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []reconcile.Request {
+		rulesList, err := listRules(ctx, reader, client.InNamespace(o.GetName()))
+		if err != nil {
+			return nil
+		}
+		requests := make([]reconcile.Request, 0, len(rulesList.Items))
+		for _, rule := range rulesList.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: rule.Name, Namespace: rule.Namespace}})
+		}
+		return requests
+	})
+
+	labelsChanged := predicate.Funcs{
+		CreateFunc:  func(e event.CreateEvent) bool { return true },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return c.NamespaceSelector.Matches(labels.Set(e.ObjectOld.GetLabels())) != c.NamespaceSelector.Matches(labels.Set(e.ObjectNew.GetLabels()))
+		},
+	}
+	return enqueue, labelsChanged
+}
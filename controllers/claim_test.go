@@ -0,0 +1,221 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func TestClaimMatches(t *testing.T) {
+
+	edge := "edge"
+	plain := newValidRule("plain", "default")
+	classed := newValidRule("classed", "default")
+	classed.Spec.OathkeeperClass = &edge
+	labelled := newValidRule("labelled", "default")
+	labelled.Labels = map[string]string{"tier": "public"}
+	rules := oathkeeperv1alpha1.RuleList{Items: []oathkeeperv1alpha1.Rule{plain, classed, labelled}}
+
+	t.Run("should claim Rules without a class by default", func(t *testing.T) {
+		assert.Equal(t, []string{"plain", "labelled"}, names(Claim{}.Filter(rules)))
+	})
+
+	t.Run("should claim Rules of its class only", func(t *testing.T) {
+		assert.Equal(t, []string{"classed"}, names(Claim{Class: edge}.Filter(rules)))
+	})
+
+	t.Run("should claim Rules matching the selector only", func(t *testing.T) {
+
+		//given
+		selector, err := labels.Parse("tier=public")
+		require.NoError(t, err)
+
+		//then
+		assert.Equal(t, []string{"labelled"}, names(Claim{Selector: selector}.Filter(rules)))
+	})
+
+	t.Run("should pass updates of Rules that leave the claim", func(t *testing.T) {
+
+		//given
+		p := Claim{}.predicate()
+
+		//then
+		assert.True(t, p.Update(event.UpdateEvent{ObjectOld: &plain, ObjectNew: &classed}))
+		assert.False(t, p.Update(event.UpdateEvent{ObjectOld: &classed, ObjectNew: &classed}))
+		assert.False(t, p.Create(event.CreateEvent{Object: &classed}))
+	})
+}
+
+func TestClaimNamespaces(t *testing.T) {
+
+	ctx := context.Background()
+	selector, err := labels.Parse("oathkeeper.ory.sh/enabled=true")
+	require.NoError(t, err)
+	claim := Claim{NamespaceSelector: selector}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiv1.AddToScheme(scheme))
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&apiv1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "enabled", Labels: map[string]string{"oathkeeper.ory.sh/enabled": "true"}}},
+		&apiv1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "disabled"}},
+	).Build()
+
+	t.Run("should keep Rules of labelled namespaces only", func(t *testing.T) {
+
+		//given
+		rules := oathkeeperv1alpha1.RuleList{Items: []oathkeeperv1alpha1.Rule{
+			newValidRule("r1", "enabled"),
+			newValidRule("r2", "disabled"),
+			newValidRule("r3", "missing"),
+		}}
+
+		//when
+		actual, err := claim.FilterNamespaces(ctx, reader, rules)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"r1"}, names(actual))
+	})
+
+	t.Run("should claim namespaces by their labels", func(t *testing.T) {
+		for ns, expected := range map[string]bool{"enabled": true, "disabled": false, "missing": false} {
+			actual, err := claim.namespaceClaimed(ctx, reader, ns)
+			require.NoError(t, err)
+			assert.Equal(t, expected, actual, ns)
+		}
+	})
+
+	t.Run("should claim every namespace without a selector", func(t *testing.T) {
+		actual, err := Claim{}.namespaceClaimed(ctx, reader, "disabled")
+		require.NoError(t, err)
+		assert.True(t, actual)
+	})
+}
+
+func TestUnclaimedRuleReconcile(t *testing.T) {
+
+	ctx := context.Background()
+
+	t.Run("should report a Rule unclaimed after the grace period", func(t *testing.T) {
+
+		//given
+		rule := newValidRule("r1", "default")
+		rule.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+		reconciler, recorder := newUnclaimedRuleReconciler(t, &rule)
+
+		//when
+		result, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "r1", Namespace: "default"}})
+
+		//then
+		require.NoError(t, err)
+		assert.Zero(t, result.RequeueAfter)
+		require.Len(t, recorder.Events, 1)
+		assert.Contains(t, <-recorder.Events, ReasonUnclaimed)
+	})
+
+	t.Run("should wait for the grace period of a new Rule", func(t *testing.T) {
+
+		//given
+		rule := newValidRule("r1", "default")
+		rule.CreationTimestamp = metav1.NewTime(time.Now())
+		reconciler, recorder := newUnclaimedRuleReconciler(t, &rule)
+
+		//when
+		result, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "r1", Namespace: "default"}})
+
+		//then
+		require.NoError(t, err)
+		assert.NotZero(t, result.RequeueAfter)
+		assert.Empty(t, recorder.Events)
+	})
+
+	t.Run("should not report a claimed Rule", func(t *testing.T) {
+
+		//given
+		rule := newValidRule("r1", "default")
+		rule.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+		rule.Status.ClaimedBy = stringPtr(Claim{}.String())
+		reconciler, recorder := newUnclaimedRuleReconciler(t, &rule)
+
+		//when
+		_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "r1", Namespace: "default"}})
+
+		//then
+		require.NoError(t, err)
+		assert.Empty(t, recorder.Events)
+	})
+
+	t.Run("should report an unclaimed RuleSet", func(t *testing.T) {
+
+		//given
+		ruleSet := &oathkeeperv1alpha1.RuleSet{ObjectMeta: metav1.ObjectMeta{
+			Name: "api", Namespace: "default", CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+		}}
+		reconciler, recorder := newUnclaimedRuleReconciler(t, ruleSet)
+		reconciler.Kind = KindRuleSet
+
+		//when
+		_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "api", Namespace: "default"}})
+
+		//then
+		require.NoError(t, err)
+		require.Len(t, recorder.Events, 1)
+		assert.Contains(t, <-recorder.Events, "RuleSet is not claimed")
+	})
+
+	t.Run("should not report a claimed ClusterRule", func(t *testing.T) {
+
+		//given
+		clusterRule := newValidClusterRule("r1")
+		clusterRule.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+		clusterRule.Status.ClaimedBy = stringPtr(Claim{}.String())
+		reconciler, recorder := newUnclaimedRuleReconciler(t, &clusterRule)
+		reconciler.Kind = KindClusterRule
+
+		//when
+		_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "r1"}})
+
+		//then
+		require.NoError(t, err)
+		assert.Empty(t, recorder.Events)
+	})
+}
+
+func newUnclaimedRuleReconciler(t *testing.T, rule client.Object) (*UnclaimedRuleReconciler, *events.FakeRecorder) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, oathkeeperv1alpha1.AddToScheme(scheme))
+
+	recorder := events.NewFakeRecorder(10)
+	return &UnclaimedRuleReconciler{
+		Client:      fake.NewClientBuilder().WithScheme(scheme).WithObjects(rule).Build(),
+		Log:         logr.Discard(),
+		Recorder:    recorder,
+		GracePeriod: DefaultUnclaimedGracePeriod,
+	}, recorder
+}
+
+func names(rl oathkeeperv1alpha1.RuleList) []string {
+	var result []string
+	for _, rule := range rl.Items {
+		result = append(result, rule.Name)
+	}
+	return result
+}
@@ -0,0 +1,209 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ClusterRuleReconciler reconciles ClusterRules. They are validated like Rules and rendered into the default target
+// next to them, taking precedence over Rules with the same ID.
+type ClusterRuleReconciler struct {
+	*RuleReconciler
+}
+
+// +kubebuilder:rbac:groups=oathkeeper.ory.sh,resources=clusterrules,verbs=get;list;watch;update
+
+// Reconcile validates and finalizes a ClusterRule and renders the default target
+func (r *ClusterRuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	var clusterRule oathkeeperv1alpha1.ClusterRule
+	if err := r.Get(ctx, req.NamespacedName, &clusterRule); err != nil {
+		if apierrs.IsNotFound(err) {
+			// just return here, the finalizers have already run
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	rule := clusterRule.ToRule()
+	claimed := r.Claim.Matches(&rule)
+	deleting := !clusterRule.DeletionTimestamp.IsZero()
+
+	switch {
+	case !claimed:
+		// the ClusterRule is meant for another instance, only render it out of the default target
+		if err := r.release(ctx, &clusterRule); err != nil {
+			r.Log.Error(err, "unable to release ClusterRule")
+			return ctrl.Result{Requeue: true}, nil
+		}
+	case deleting:
+		if containsString(clusterRule.Finalizers, FinalizerName) {
+			clusterRule.Finalizers = removeString(clusterRule.Finalizers, FinalizerName)
+			if err := r.Update(ctx, &clusterRule); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+	default:
+		invalid, err := r.check(ctx, &rule)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		warnings, err := r.deprecationWarnings(ctx, &rule)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		clusterRule.Status.Validation = &oathkeeperv1alpha1.Validation{Valid: boolPtr(invalid == nil), Warnings: warnings}
+		if invalid != nil {
+			clusterRule.Status.Validation.Error = stringPtr(invalid.Error())
+			r.Log.Info(fmt.Sprintf("validation error in ClusterRule %s: \"%s\"", clusterRule.Name, invalid))
+		}
+		clusterRule.Status.Matches = r.IDScheme.ApplyTo(&rule).MatchStatuses()
+		clusterRule.Status.ClaimedBy = stringPtr(r.Claim.String())
+		if !containsString(clusterRule.Finalizers, FinalizerName) {
+			clusterRule.Finalizers = append(clusterRule.Finalizers, FinalizerName)
+		}
+		if err := r.Update(ctx, &clusterRule); err != nil {
+			r.Log.Error(err, "unable to update ClusterRule")
+			//Invoke requeue directly without logging error with whole stacktrace
+			return ctrl.Result{Requeue: true}, nil
+		}
+	}
+
+	rulesList, err := r.claimedRules(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	clusterRules, err := listClusterRules(ctx, r.Client, true, r.Claim, r.IDScheme, r.Defaulter, r.Presets)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if deleting {
+		clusterRules = clusterRules.FilterOutRule(rule)
+	}
+
+	r.write(ctx, renderedRules(rulesList, clusterRules), &rule)
+	return ctrl.Result{}, nil
+}
+
+// release clears the claim of this instance from a ClusterRule it no longer claims, see RuleReconciler.release
+func (r *ClusterRuleReconciler) release(ctx context.Context, clusterRule *oathkeeperv1alpha1.ClusterRule) error {
+	claimedBy := clusterRule.Status.ClaimedBy
+	ours := claimedBy != nil && *claimedBy == r.Claim.String()
+	if !ours && claimedBy != nil {
+		return nil
+	}
+
+	deleting := !clusterRule.DeletionTimestamp.IsZero() && containsString(clusterRule.Finalizers, FinalizerName)
+	if !ours && !deleting {
+		return nil
+	}
+
+	clusterRule.Status.ClaimedBy = nil
+	if deleting {
+		clusterRule.Finalizers = removeString(clusterRule.Finalizers, FinalizerName)
+	}
+	return r.Update(ctx, clusterRule)
+}
+
+// SetupWithManager registers the reconciler for ClusterRules
+func (r *ClusterRuleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named("clusterrule").
+		For(&oathkeeperv1alpha1.ClusterRule{}, builder.WithPredicates(r.Claim.predicate()))
+	return r.watchDependencies(b).Complete(r)
+}
+
+// watchDependencies watches what the validation of ClusterRules depends on besides the ClusterRules themselves. Only
+// cluster-scoped defaults and presets apply to them.
+func (r *ClusterRuleReconciler) watchDependencies(b *builder.Builder) *builder.Builder {
+	b = r.watchIDs(b)
+	dependencies := handler.EnqueueRequestsFromMapFunc(r.clusterRulesUsing)
+	if r.Defaulter != nil && r.Defaulter.Cluster {
+		b = b.Watches(&oathkeeperv1alpha1.ClusterRuleDefaults{}, dependencies)
+	}
+	if r.Presets != nil && r.Presets.Cluster {
+		b = b.Watches(&oathkeeperv1alpha1.ClusterHandlerPreset{}, dependencies)
+	}
+	return b
+}
+
+// clusterRulesUsing returns requests for the claimed ClusterRules a ClusterRuleDefaults or ClusterHandlerPreset applies
+// to, so they are validated again. Presets are matched after the defaults are applied, which may reference them.
+func (r *ClusterRuleReconciler) clusterRulesUsing(ctx context.Context, o client.Object) []reconcile.Request {
+	var clusterRules oathkeeperv1alpha1.ClusterRuleList
+	if err := r.List(ctx, &clusterRules); err != nil {
+		return nil
+	}
+	rules, err := r.Defaulter.Apply(ctx, r.Claim.Filter(clusterRules.ToRules()))
+	if err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, rule := range rules.Items {
+		var affected bool
+		switch o.(type) {
+		case *oathkeeperv1alpha1.ClusterRuleDefaults:
+			affected = o.GetName() == oathkeeperv1alpha1.DefaultsName
+		case *oathkeeperv1alpha1.ClusterHandlerPreset:
+			affected = references(rule, types.NamespacedName{Name: o.GetName()})
+		}
+		if affected {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: rule.Name}})
+		}
+	}
+	return requests
+}
+
+// listClusterRules returns the claimed ClusterRules as Rules, with their defaults and presets resolved.
+// Without access to ClusterRules the list is empty.
+func listClusterRules(ctx context.Context, reader client.Reader, enabled bool, claim Claim, ids *RuleIDScheme, defaulter *RuleDefaulter, presets *PresetResolver) (oathkeeperv1alpha1.RuleList, error) {
+	if !enabled {
+		return oathkeeperv1alpha1.RuleList{}, nil
+	}
+
+	var clusterRules oathkeeperv1alpha1.ClusterRuleList
+	if err := reader.List(ctx, &clusterRules); err != nil {
+		return oathkeeperv1alpha1.RuleList{}, err
+	}
+	rules, err := defaulter.Apply(ctx, ids.Apply(claim.Filter(clusterRules.ToRules())))
+	if err != nil {
+		return rules, err
+	}
+	return presets.Resolve(ctx, rules)
+}
+
+// withClusterRules adds the ClusterRules to the Rules of the default target. Rules sharing an ID with a ClusterRule
+// are left out, they are reported as invalid.
+func withClusterRules(rules, clusterRules oathkeeperv1alpha1.RuleList) oathkeeperv1alpha1.RuleList {
+	ids := map[string]bool{}
+	for _, rule := range clusterRules.Items {
+		for _, id := range rule.IDs() {
+			ids[id] = true
+		}
+	}
+
+	rlCopy := rules
+	rlCopy.Items = make([]oathkeeperv1alpha1.Rule, 0, len(rules.Items)+len(clusterRules.Items))
+	for _, rule := range rules.Items {
+		if !slices.ContainsFunc(rule.IDs(), func(id string) bool { return ids[id] }) {
+			rlCopy.Items = append(rlCopy.Items, rule)
+		}
+	}
+	rlCopy.Items = append(rlCopy.Items, clusterRules.Items...)
+	return rlCopy
+}
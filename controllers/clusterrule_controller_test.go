@@ -0,0 +1,232 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	"github.com/ory/oathkeeper-maester/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestWithClusterRules(t *testing.T) {
+
+	//given
+	rules := oathkeeperv1alpha1.RuleList{Items: []oathkeeperv1alpha1.Rule{
+		newValidRule("r1", "default"),
+		newValidRule("r2", "default"),
+	}}
+	clusterRules := oathkeeperv1alpha1.RuleList{Items: []oathkeeperv1alpha1.Rule{
+		newValidRule("r1.default", ""),
+		newValidRule("health", ""),
+	}}
+
+	//when
+	actual := withClusterRules(rules, clusterRules)
+
+	//then
+	var ids []string
+	for _, rule := range actual.Items {
+		ids = append(ids, rule.ToRuleJSON().ID)
+	}
+	assert.Equal(t, []string{"r2.default", "r1.default", "health"}, ids)
+}
+
+func TestClusterRuleCollision(t *testing.T) {
+
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, oathkeeperv1alpha1.AddToScheme(scheme))
+
+	clusterRule := newValidClusterRule("r1.default")
+	reconciler := &RuleReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(&clusterRule).Build(),
+		Log:    logr.Discard(),
+		ValidationConfig: validation.Config{
+			AuthenticatorsAvailable: []string{"unauthorized"},
+			AuthorizersAvailable:    []string{"deny"},
+			MutatorsAvailable:       []string{"noop"},
+		},
+		ClusterRules: true,
+	}
+
+	t.Run("should mark Rules with the ID of a ClusterRule invalid", func(t *testing.T) {
+
+		//given
+		rule := newValidRule("r1", "default")
+
+		//when
+		invalid, err := reconciler.check(ctx, &rule)
+
+		//then
+		require.NoError(t, err)
+		assert.EqualError(t, invalid, "rule ID r1.default is already used by ClusterRule r1.default")
+	})
+
+	t.Run("should not look up ClusterRules in namespaced mode", func(t *testing.T) {
+
+		//given
+		namespaced := *reconciler
+		namespaced.ClusterRules = false
+		rule := newValidRule("r1", "default")
+
+		//when
+		invalid, err := namespaced.check(ctx, &rule)
+
+		//then
+		require.NoError(t, err)
+		assert.NoError(t, invalid)
+	})
+
+	t.Run("should accept the ClusterRule itself", func(t *testing.T) {
+
+		//given
+		rule := clusterRule.ToRule()
+
+		//when
+		invalid, err := reconciler.check(ctx, &rule)
+
+		//then
+		require.NoError(t, err)
+		assert.NoError(t, invalid)
+	})
+}
+
+func TestConfigMapReconcileClusterRules(t *testing.T) {
+
+	//given
+	ctx := context.Background()
+	rule := newValidRule("r1", "default")
+	clusterRule := newValidClusterRule("health")
+	reconciler, _ := newConfigMapReconciler(t, &rule, &clusterRule)
+	reconciler.ClusterRules = true
+
+	//when
+	_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: defaultConfigMap})
+
+	//then
+	require.NoError(t, err)
+	expected, err := oathkeeperv1alpha1.RuleList{Items: []oathkeeperv1alpha1.Rule{rule, clusterRule.ToRule()}}.ToOathkeeperRules()
+	require.NoError(t, err)
+	var actual apiv1.ConfigMap
+	require.NoError(t, reconciler.Get(ctx, defaultConfigMap, &actual))
+	assert.Equal(t, string(expected), actual.Data["access-rules.json"])
+}
+
+func TestClusterRuleDependencies(t *testing.T) {
+
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiv1.AddToScheme(scheme))
+	require.NoError(t, oathkeeperv1alpha1.AddToScheme(scheme))
+
+	usingPreset := newValidClusterRule("uses-preset")
+	usingPreset.Spec.Mutators = []*oathkeeperv1alpha1.Mutator{{Handler: &oathkeeperv1alpha1.Handler{
+		PresetRef: &oathkeeperv1alpha1.PresetRef{Name: "hydrator", Kind: oathkeeperv1alpha1.ClusterHandlerPresetKind},
+	}}}
+	plain := newValidClusterRule("plain")
+	preset := &oathkeeperv1alpha1.ClusterHandlerPreset{
+		ObjectMeta: metav1.ObjectMeta{Name: "hydrator"},
+		Spec:       oathkeeperv1alpha1.HandlerPresetSpec{Name: "noop"},
+	}
+
+	newReconciler := func(objs ...client.Object) *ClusterRuleReconciler {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+		return &ClusterRuleReconciler{RuleReconciler: &RuleReconciler{
+			Client:    c,
+			Log:       logr.Discard(),
+			Defaulter: &RuleDefaulter{Reader: c, Cluster: true},
+			Presets:   &PresetResolver{Reader: c, Cluster: true},
+			ValidationConfig: validation.Config{
+				AuthenticatorsAvailable: []string{"unauthorized"},
+				AuthorizersAvailable:    []string{"deny"},
+				MutatorsAvailable:       []string{"noop"},
+			},
+			ClusterRules: true,
+			OperatorMode: &ConfigMapOperator{
+				Client:           c,
+				Log:              logr.Discard(),
+				DefaultConfigMap: defaultConfigMap,
+				RulesFileName:    "access-rules.json",
+				Recorder:         events.NewFakeRecorder(10),
+			},
+		}}
+	}
+
+	t.Run("should enqueue the ClusterRules using a ClusterHandlerPreset", func(t *testing.T) {
+
+		//given
+		reconciler := newReconciler(&usingPreset, &plain)
+
+		//when
+		requests := reconciler.clusterRulesUsing(ctx, preset)
+
+		//then
+		assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "uses-preset"}}}, requests)
+	})
+
+	t.Run("should enqueue every ClusterRule when the ClusterRuleDefaults change", func(t *testing.T) {
+
+		//given
+		reconciler := newReconciler(&usingPreset, &plain)
+		defaults := &oathkeeperv1alpha1.ClusterRuleDefaults{ObjectMeta: metav1.ObjectMeta{Name: oathkeeperv1alpha1.DefaultsName}}
+
+		//when
+		requests := reconciler.clusterRulesUsing(ctx, defaults)
+
+		//then
+		assert.ElementsMatch(t, []reconcile.Request{
+			{NamespacedName: types.NamespacedName{Name: "uses-preset"}},
+			{NamespacedName: types.NamespacedName{Name: "plain"}},
+		}, requests)
+	})
+
+	t.Run("should validate a ClusterRule again once its missing preset is created", func(t *testing.T) {
+
+		//given
+		reconciler := newReconciler(&usingPreset)
+		request := ctrl.Request{NamespacedName: types.NamespacedName{Name: "uses-preset"}}
+		_, err := reconciler.Reconcile(ctx, request)
+		require.NoError(t, err)
+		var actual oathkeeperv1alpha1.ClusterRule
+		require.NoError(t, reconciler.Get(ctx, request.NamespacedName, &actual))
+		require.False(t, *actual.Status.Validation.Valid)
+
+		//when
+		require.NoError(t, reconciler.Create(ctx, preset.DeepCopy()))
+		for _, req := range reconciler.clusterRulesUsing(ctx, preset) {
+			_, err := reconciler.Reconcile(ctx, req)
+			require.NoError(t, err)
+		}
+
+		//then
+		require.NoError(t, reconciler.Get(ctx, request.NamespacedName, &actual))
+		assert.True(t, *actual.Status.Validation.Valid)
+		var configMap apiv1.ConfigMap
+		require.NoError(t, reconciler.Get(ctx, defaultConfigMap, &configMap))
+		assert.Contains(t, configMap.Data["access-rules.json"], `"id": "uses-preset"`)
+	})
+}
+
+func newValidClusterRule(name string) oathkeeperv1alpha1.ClusterRule {
+	rule := newValidRule(name, "")
+	return oathkeeperv1alpha1.ClusterRule{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       rule.Spec,
+		Status:     rule.Status,
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	apiv1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// ReasonDriftCorrected is the reason of Events emitted when a managed ConfigMap is restored
+	ReasonDriftCorrected = "DriftCorrected"
+)
+
+// ConfigMapReconciler watches ConfigMaps managed by maester and restores the rendered rules whenever they are edited or deleted by hand
+type ConfigMapReconciler struct {
+	*ConfigMapOperator
+	Claim     Claim
+	Defaulter *RuleDefaulter
+	Presets   *PresetResolver
+	// ClusterRules renders ClusterRules into the default target
+	ClusterRules bool
+	// IDScheme derives the IDs of Rules without spec.id
+	IDScheme *RuleIDScheme
+}
+
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
+
+// Reconcile compares a managed ConfigMap with the rules rendered for it and rewrites it on drift
+func (r *ConfigMapReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	var listOpts []client.ListOption
+	isDefault := req.NamespacedName == r.DefaultConfigMap
+	if !isDefault {
+		listOpts = append(listOpts, client.InNamespace(req.Namespace))
+	}
+	rulesList, err := listRules(ctx, r.Client, listOpts...)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	rulesList = r.IDScheme.Apply(rulesList)
+	if isDefault {
+		rulesList = r.Claim.Filter(rulesList)
+	} else {
+		rulesList = r.Claim.Filter(rulesList).FilterConfigMapName(&req.Name)
+		if len(rulesList.Items) == 0 {
+			// no Rule renders into this ConfigMap anymore, so there is nothing to compare against
+			return ctrl.Result{}, nil
+		}
+	}
+
+	rulesList, err = r.Claim.FilterNamespaces(ctx, r.Client, rulesList)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	rulesList, err = r.Defaulter.Apply(ctx, rulesList)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	rulesList, err = r.Presets.Resolve(ctx, rulesList)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	var clusterRules oathkeeperv1alpha1.RuleList
+	if isDefault {
+		clusterRules, err = listClusterRules(ctx, r.Client, r.ClusterRules, r.Claim, r.IDScheme, r.Defaulter, r.Presets)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	rendered, err := r.render(ctx, req.NamespacedName, renderedRules(rulesList, clusterRules))
+	if err != nil {
+		var notMergeable *NotMergeableError
+		if errors.As(err, &notMergeable) {
+			// the Rule reconciler reports this on the Rules of the target
+			r.Log.Info(fmt.Sprintf("not restoring ConfigMap %s: %s", req.NamespacedName, err))
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	desired := rendered.configMaps
+
+	refs := make([]types.NamespacedName, 0, len(desired))
+	drifted := map[types.NamespacedName]bool{}
+	for ref, data := range desired {
+		refs = append(refs, ref)
+		var configMap apiv1.ConfigMap
+		if err := r.Get(ctx, ref, &configMap); err != nil {
+			if !apierrs.IsNotFound(err) {
+				return ctrl.Result{}, err
+			}
+			drifted[ref] = false
+			continue
+		}
+		if !reflect.DeepEqual(managedData(&configMap, data), data) {
+			drifted[ref] = true
+		}
+	}
+
+	if len(drifted) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.checkSize(req.NamespacedName, rendered, nil); err != nil {
+		var tooLarge *TooLargeError
+		if errors.As(err, &tooLarge) {
+			// the Rule reconciler reports this on the offending Rules, the previous content is kept
+			r.Log.Info(fmt.Sprintf("not restoring ConfigMap %s: %s", req.NamespacedName, err))
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	r.Log.Info(fmt.Sprintf("ConfigMap %s drifted from the rendered rules, restoring it", req.NamespacedName))
+	if err := r.apply(ctx, req.NamespacedName, desired); err != nil {
+		var notOwned *NotOwnedError
+		if errors.As(err, &notOwned) {
+			r.Log.Info(fmt.Sprintf("not restoring ConfigMap %s: %s", req.NamespacedName, err))
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	sort.Slice(refs, func(i, j int) bool { return refs[i].String() < refs[j].String() })
+	for _, ref := range refs {
+		existed, ok := drifted[ref]
+		if !ok {
+			continue
+		}
+
+		var configMap apiv1.ConfigMap
+		if err := r.Get(ctx, ref, &configMap); err != nil {
+			r.Log.Error(err, "unable to fetch restored ConfigMap, skipping event")
+			continue
+		}
+
+		if existed {
+			r.Recorder.Eventf(&configMap, nil, apiv1.EventTypeWarning, ReasonDriftCorrected, "Update",
+				"ConfigMap was modified outside of oathkeeper-maester, restored it from the rendered rules")
+		} else {
+			r.Recorder.Eventf(&configMap, nil, apiv1.EventTypeWarning, ReasonDriftCorrected, "Create",
+				"ConfigMap was deleted outside of oathkeeper-maester, recreated it from the rendered rules")
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager registers the reconciler for ConfigMaps carrying ManagedByLabel, shard ConfigMaps are reconciled through their target
+func (r *ConfigMapReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	isTarget := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetLabels()[ManagedByLabel] == ManagedByValue && o.GetLabels()[ShardOfLabel] == ""
+	})
+	isShard := predicate.NewPredicateFuncs(func(o client.Object) bool {
+		return o.GetLabels()[ManagedByLabel] == ManagedByValue && o.GetLabels()[ShardOfLabel] != ""
+	})
+	toTarget := handler.EnqueueRequestsFromMapFunc(func(_ context.Context, o client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: o.GetLabels()[ShardOfLabel], Namespace: o.GetNamespace()}}}
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("configmap").
+		For(&apiv1.ConfigMap{}, builder.WithPredicates(isTarget)).
+		Watches(&apiv1.ConfigMap{}, toTarget, builder.WithPredicates(isShard)).
+		Complete(r)
+}
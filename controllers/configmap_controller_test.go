@@ -0,0 +1,170 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var defaultConfigMap = types.NamespacedName{Name: "oathkeeper-rules", Namespace: "oathkeeper-maester-system"}
+
+func TestConfigMapReconcile(t *testing.T) {
+
+	ctx := context.Background()
+	expected, err := oathkeeperv1alpha1.RuleList{Items: []oathkeeperv1alpha1.Rule{newValidRule("r1", "default")}}.ToOathkeeperRules()
+	require.NoError(t, err)
+
+	t.Run("should restore a ConfigMap edited by hand", func(t *testing.T) {
+
+		//given
+		configMap := newManagedConfigMap(defaultConfigMap, "[]")
+		rule := newValidRule("r1", "default")
+		reconciler, recorder := newConfigMapReconciler(t, &rule, configMap)
+
+		//when
+		_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: defaultConfigMap})
+
+		//then
+		require.NoError(t, err)
+		var actual apiv1.ConfigMap
+		require.NoError(t, reconciler.Get(ctx, defaultConfigMap, &actual))
+		assert.Equal(t, string(expected), actual.Data["access-rules.json"])
+		require.Len(t, recorder.Events, 1)
+		assert.Contains(t, <-recorder.Events, ReasonDriftCorrected)
+	})
+
+	t.Run("should recreate a deleted ConfigMap", func(t *testing.T) {
+
+		//given
+		rule := newValidRule("r1", "default")
+		reconciler, recorder := newConfigMapReconciler(t, &rule)
+
+		//when
+		_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: defaultConfigMap})
+
+		//then
+		require.NoError(t, err)
+		var actual apiv1.ConfigMap
+		require.NoError(t, reconciler.Get(ctx, defaultConfigMap, &actual))
+		assert.Equal(t, string(expected), actual.Data["access-rules.json"])
+		assert.Equal(t, ManagedByValue, actual.Labels[ManagedByLabel])
+		require.Len(t, recorder.Events, 1)
+	})
+
+	t.Run("should leave an up to date ConfigMap alone", func(t *testing.T) {
+
+		//given
+		configMap := newManagedConfigMap(defaultConfigMap, string(expected))
+		rule := newValidRule("r1", "default")
+		reconciler, recorder := newConfigMapReconciler(t, &rule, configMap)
+
+		//when
+		_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: defaultConfigMap})
+
+		//then
+		require.NoError(t, err)
+		assert.Empty(t, recorder.Events)
+	})
+
+	t.Run("should render the same Rules as the Rule reconciler while one is being deleted", func(t *testing.T) {
+
+		//given
+		configMap := newManagedConfigMap(defaultConfigMap, string(expected))
+		rule := newValidRule("r1", "default")
+		deleting := newValidRule("r2", "default")
+		deleting.Finalizers = []string{FinalizerName}
+		deleting.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		reconciler, recorder := newConfigMapReconciler(t, &rule, &deleting, configMap)
+		ruleReconciler := &RuleReconciler{Client: reconciler.Client}
+
+		//when
+		_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: defaultConfigMap})
+		targetRules, targetErr := ruleReconciler.targetRules(ctx, oathkeeperv1alpha1.RuleList{Items: []oathkeeperv1alpha1.Rule{rule, deleting}}, &rule)
+
+		//then
+		require.NoError(t, err)
+		require.NoError(t, targetErr)
+		assert.Empty(t, recorder.Events)
+		require.Len(t, targetRules.Items, 1)
+		assert.Equal(t, "r1", targetRules.Items[0].Name)
+	})
+
+	t.Run("should ignore ConfigMaps no Rule renders into", func(t *testing.T) {
+
+		//given
+		other := types.NamespacedName{Name: "other", Namespace: "default"}
+		rule := newValidRule("r1", "default")
+		reconciler, recorder := newConfigMapReconciler(t, &rule)
+
+		//when
+		_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: other})
+
+		//then
+		require.NoError(t, err)
+		assert.Empty(t, recorder.Events)
+		var actual apiv1.ConfigMap
+		assert.Error(t, reconciler.Get(ctx, other, &actual))
+	})
+}
+
+func newConfigMapReconciler(t *testing.T, objs ...client.Object) (*ConfigMapReconciler, *events.FakeRecorder) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiv1.AddToScheme(scheme))
+	require.NoError(t, oathkeeperv1alpha1.AddToScheme(scheme))
+
+	recorder := events.NewFakeRecorder(10)
+	return &ConfigMapReconciler{
+		ConfigMapOperator: &ConfigMapOperator{
+			Client:           fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+			Log:              logr.Discard(),
+			DefaultConfigMap: defaultConfigMap,
+			RulesFileName:    "access-rules.json",
+			Recorder:         recorder,
+		},
+	}, recorder
+}
+
+func newManagedConfigMap(name types.NamespacedName, data string) *apiv1.ConfigMap {
+	return &apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.Name,
+			Namespace: name.Namespace,
+			Labels:    map[string]string{ManagedByLabel: ManagedByValue},
+		},
+		Data: map[string]string{"access-rules.json": data},
+	}
+}
+
+func newValidRule(name, namespace string) oathkeeperv1alpha1.Rule {
+	return oathkeeperv1alpha1.Rule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: oathkeeperv1alpha1.RuleSpec{
+			Match: &oathkeeperv1alpha1.Match{
+				URL:     "http://my-app/" + name,
+				Methods: []string{"GET"},
+			},
+		},
+		Status: oathkeeperv1alpha1.RuleStatus{
+			Validation: &oathkeeperv1alpha1.Validation{Valid: boolPtr(true)},
+		},
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"fmt"
+	"strings"
+
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DecisionOnlyTargets are the targets whose rules are rendered without upstream, for Oathkeeper deployments that only
+// serve the decisions API
+type DecisionOnlyTargets struct {
+	// All covers every target, as in sidecar mode where all Rules are rendered into one file
+	All bool
+	// ConfigMaps are the decision-only ConfigMaps
+	ConfigMaps map[types.NamespacedName]bool
+	// Default is the target of Rules without spec.configMapName and of ClusterRules
+	Default types.NamespacedName
+}
+
+// ParseDecisionOnlyTargets parses a comma-separated list of namespace/name of ConfigMaps, which may include the
+// default target. An empty list returns nil.
+func ParseDecisionOnlyTargets(list string, defaultTarget types.NamespacedName) (*DecisionOnlyTargets, error) {
+	targets := &DecisionOnlyTargets{ConfigMaps: map[types.NamespacedName]bool{}, Default: defaultTarget}
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		namespace, name, ok := strings.Cut(entry, "/")
+		if !ok || namespace == "" || name == "" {
+			return nil, fmt.Errorf("decision-only target %q is not of the form namespace/name", entry)
+		}
+		targets.ConfigMaps[types.NamespacedName{Namespace: namespace, Name: name}] = true
+	}
+	if len(targets.ConfigMaps) == 0 {
+		return nil, nil
+	}
+	return targets, nil
+}
+
+// Contains tells whether the Rule is rendered into a decision-only target. A nil DecisionOnlyTargets contains nothing.
+func (t *DecisionOnlyTargets) Contains(rule oathkeeperv1alpha1.Rule) bool {
+	if t == nil {
+		return false
+	}
+	if t.All {
+		return true
+	}
+	target := t.Default
+	if rule.Namespace != "" && rule.Spec.ConfigMapName != nil && *rule.Spec.ConfigMapName != "" {
+		target = types.NamespacedName{Namespace: rule.Namespace, Name: *rule.Spec.ConfigMapName}
+	}
+	return t.ConfigMaps[target]
+}
+
+// ContainsTarget tells whether the target is decision-only. A nil DecisionOnlyTargets contains nothing.
+func (t *DecisionOnlyTargets) ContainsTarget(target types.NamespacedName) bool {
+	if t == nil {
+		return false
+	}
+	return t.All || t.ConfigMaps[target]
+}
+
+// Baseline returns the baseline rules as they are rendered into the target, without upstream in a decision-only one
+func (t *DecisionOnlyTargets) Baseline(baseline *oathkeeperv1alpha1.Baseline, target types.NamespacedName) (*oathkeeperv1alpha1.Baseline, error) {
+	if !t.ContainsTarget(target) {
+		return baseline, nil
+	}
+	return baseline.DecisionOnly()
+}
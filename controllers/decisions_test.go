@@ -0,0 +1,162 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParseDecisionOnlyTargets(t *testing.T) {
+
+	t.Run("should return nil without targets", func(t *testing.T) {
+		targets, err := ParseDecisionOnlyTargets(" ", defaultConfigMap)
+		require.NoError(t, err)
+		assert.Nil(t, targets)
+	})
+
+	t.Run("should reject targets without namespace", func(t *testing.T) {
+		_, err := ParseDecisionOnlyTargets("team-a/decisions,decisions", defaultConfigMap)
+		assert.EqualError(t, err, `decision-only target "decisions" is not of the form namespace/name`)
+	})
+
+	t.Run("should contain the Rules of the listed targets", func(t *testing.T) {
+
+		//given
+		targets, err := ParseDecisionOnlyTargets("team-a/decisions, "+defaultConfigMap.String(), defaultConfigMap)
+		require.NoError(t, err)
+		inConfigMap := newValidRule("r1", "team-a")
+		inConfigMap.Spec.ConfigMapName = stringPtr("decisions")
+		inOtherConfigMap := newValidRule("r2", "team-b")
+		inOtherConfigMap.Spec.ConfigMapName = stringPtr("decisions")
+		clusterRule := oathkeeperv1alpha1.ClusterRule{ObjectMeta: metav1.ObjectMeta{Name: "r3"}}
+
+		//then
+		assert.True(t, targets.Contains(inConfigMap))
+		assert.False(t, targets.Contains(inOtherConfigMap))
+		assert.True(t, targets.Contains(newValidRule("r4", "team-b")), "the default target is listed")
+		assert.True(t, targets.Contains(clusterRule.ToRule()))
+		assert.False(t, (*DecisionOnlyTargets)(nil).Contains(inConfigMap))
+		assert.True(t, (&DecisionOnlyTargets{All: true}).Contains(inOtherConfigMap))
+	})
+}
+
+func TestDecisionOnlyRules(t *testing.T) {
+
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, oathkeeperv1alpha1.AddToScheme(scheme))
+	targets := &DecisionOnlyTargets{ConfigMaps: map[types.NamespacedName]bool{defaultConfigMap: true}, Default: defaultConfigMap}
+
+	t.Run("should make the Rules of decision-only targets decision-only unless they opt out", func(t *testing.T) {
+
+		//given
+		plain := newValidRule("plain", "team-a")
+		optedOut := newValidRule("opted-out", "team-a")
+		optedOut.Spec.DecisionOnly = boolPtr(false)
+		elsewhere := newValidRule("elsewhere", "team-a")
+		elsewhere.Spec.ConfigMapName = stringPtr("other")
+		defaulter := &RuleDefaulter{Reader: fake.NewClientBuilder().WithScheme(scheme).Build(), DecisionOnly: targets}
+
+		//when
+		actual, err := defaulter.Apply(ctx, oathkeeperv1alpha1.RuleList{Items: []oathkeeperv1alpha1.Rule{plain, optedOut, elsewhere}})
+
+		//then
+		require.NoError(t, err)
+		assert.True(t, *actual.Items[0].Spec.DecisionOnly)
+		assert.False(t, *actual.Items[1].Spec.DecisionOnly)
+		assert.Nil(t, actual.Items[2].Spec.DecisionOnly)
+		assert.Nil(t, plain.Spec.DecisionOnly, "Rules must not be changed")
+	})
+
+	t.Run("should render decision-only targets without upstream", func(t *testing.T) {
+
+		//given
+		rule := newValidRule("plain", "team-a")
+		reconciler, _ := newConfigMapReconciler(t, &rule, &oathkeeperv1alpha1.RuleDefaults{
+			ObjectMeta: metav1.ObjectMeta{Name: oathkeeperv1alpha1.DefaultsName, Namespace: "team-a"},
+			Spec:       oathkeeperv1alpha1.RuleDefaultsSpec{Upstream: &oathkeeperv1alpha1.UpstreamDefaults{PreserveHost: boolPtr(true)}},
+		}, newManagedConfigMap(defaultConfigMap, "[]"))
+		reconciler.Defaulter = &RuleDefaulter{Reader: reconciler.Client, DecisionOnly: targets}
+
+		//when
+		_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: defaultConfigMap})
+
+		//then
+		require.NoError(t, err)
+		var actual apiv1.ConfigMap
+		require.NoError(t, reconciler.Get(ctx, defaultConfigMap, &actual))
+		assert.Contains(t, actual.Data["access-rules.json"], `"id": "plain.team-a"`)
+		assert.NotContains(t, actual.Data["access-rules.json"], "upstream")
+		assert.NotContains(t, actual.Data["access-rules.json"], "decisionOnly")
+	})
+
+	t.Run("should render baseline rules without upstream into decision-only targets only", func(t *testing.T) {
+
+		//given
+		baseline, err := oathkeeperv1alpha1.ParseBaseline([]byte(`[{"id": "health", "match": {"url": "http://<.*>/health", "methods": ["GET"]}}]`), oathkeeperv1alpha1.BaselineLast)
+		require.NoError(t, err)
+		other := types.NamespacedName{Name: "other", Namespace: "team-a"}
+		reconciler, _ := newConfigMapReconciler(t)
+		reconciler.Baseline = baseline
+		reconciler.DecisionOnly = targets
+
+		//when
+		decisions, err1 := reconciler.desiredConfigMaps(defaultConfigMap, noRules)
+		proxied, err2 := reconciler.desiredConfigMaps(other, noRules)
+
+		//then
+		require.NoError(t, err1)
+		require.NoError(t, err2)
+		assert.Contains(t, decisions[defaultConfigMap]["access-rules.json"], `"id": "health"`)
+		assert.NotContains(t, decisions[defaultConfigMap]["access-rules.json"], "upstream")
+		assert.Contains(t, proxied[other]["access-rules.json"], "upstream")
+	})
+
+	t.Run("should refuse to render baseline rules with upstream into decision-only targets", func(t *testing.T) {
+
+		//given
+		baseline, err := oathkeeperv1alpha1.ParseBaseline([]byte(`[{"id": "health", "match": {"url": "http://<.*>/health", "methods": ["GET"]}, "upstream": {"url": "http://backend"}}]`), oathkeeperv1alpha1.BaselineLast)
+		require.NoError(t, err)
+		operator := &FilesOperator{Log: logr.Discard(), RulesFilePath: filepath.Join(t.TempDir(), "access-rules.json"), Baseline: baseline, DecisionOnly: &DecisionOnlyTargets{All: true}}
+
+		//when
+		err = operator.CreateOrUpdate(ctx, noRules, nil)
+
+		//then
+		assert.EqualError(t, err, "baseline rule health: upstream can't be set on a rule rendered into a decision-only target")
+	})
+
+	t.Run("should reject Rules of decision-only targets setting upstream", func(t *testing.T) {
+
+		//given
+		rule := newValidRule("r1", "team-a")
+		rule.Spec.Upstream = &oathkeeperv1alpha1.Upstream{URL: "http://backend"}
+		client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&rule).Build()
+		reconciler := &RuleReconciler{
+			Client:    client,
+			Log:       logr.Discard(),
+			Defaulter: &RuleDefaulter{Reader: client, DecisionOnly: targets},
+		}
+
+		//when
+		invalid, err := reconciler.check(ctx, &rule)
+
+		//then
+		require.NoError(t, err)
+		assert.EqualError(t, invalid, "upstream can't be set on a decision-only rule")
+	})
+}
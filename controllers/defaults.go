@@ -0,0 +1,118 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// RuleDefaulter fills in what Rules omit from the RuleDefaults of their namespace, then from the ClusterRuleDefaults.
+// Whatever is still unset falls back to the built-in defaults of ToRuleJSON. ClusterRules only get the ClusterRuleDefaults.
+type RuleDefaulter struct {
+	client.Reader
+	// Cluster enables ClusterRuleDefaults, namespaced instances can't read them
+	Cluster bool
+	// DecisionOnly makes the Rules of these targets decision-only unless they set spec.decisionOnly themselves
+	DecisionOnly *DecisionOnlyTargets
+}
+
+// +kubebuilder:rbac:groups=oathkeeper.ory.sh,resources=ruledefaults;clusterruledefaults,verbs=get;list;watch
+
+// Apply returns copies of the Rules with their defaults filled in, the Rules themselves are left untouched.
+// A nil RuleDefaulter returns the Rules as they are.
+func (d *RuleDefaulter) Apply(ctx context.Context, rules oathkeeperv1alpha1.RuleList) (oathkeeperv1alpha1.RuleList, error) {
+	if d == nil {
+		return rules, nil
+	}
+
+	cluster, err := d.clusterDefaults(ctx)
+	if err != nil {
+		return rules, err
+	}
+
+	byNamespace := map[string]*oathkeeperv1alpha1.RuleDefaultsSpec{}
+	rlCopy := rules
+	rlCopy.Items = make([]oathkeeperv1alpha1.Rule, 0, len(rules.Items))
+	for _, rule := range rules.Items {
+		namespaced, ok := byNamespace[rule.Namespace]
+		if !ok && rule.Namespace != "" {
+			if namespaced, err = d.namespaceDefaults(ctx, rule.Namespace); err != nil {
+				return rules, err
+			}
+			byNamespace[rule.Namespace] = namespaced
+		}
+
+		defaulted := rule.DeepCopy()
+		if defaulted.Spec.DecisionOnly == nil && d.DecisionOnly.Contains(rule) {
+			defaulted.Spec.DecisionOnly = boolPtr(true)
+		}
+		namespaced.ApplyTo(&defaulted.Spec)
+		cluster.ApplyTo(&defaulted.Spec)
+		rlCopy.Items = append(rlCopy.Items, *defaulted)
+	}
+	return rlCopy, nil
+}
+
+// ApplyTo returns a copy of the Rule with its defaults filled in
+func (d *RuleDefaulter) ApplyTo(ctx context.Context, rule *oathkeeperv1alpha1.Rule) (*oathkeeperv1alpha1.Rule, error) {
+	defaulted, err := d.Apply(ctx, oathkeeperv1alpha1.RuleList{Items: []oathkeeperv1alpha1.Rule{*rule}})
+	if err != nil {
+		return nil, err
+	}
+	return &defaulted.Items[0], nil
+}
+
+func (d *RuleDefaulter) namespaceDefaults(ctx context.Context, namespace string) (*oathkeeperv1alpha1.RuleDefaultsSpec, error) {
+	var defaults oathkeeperv1alpha1.RuleDefaults
+	if err := d.Get(ctx, types.NamespacedName{Name: oathkeeperv1alpha1.DefaultsName, Namespace: namespace}, &defaults); err != nil {
+		if apierrs.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &defaults.Spec, nil
+}
+
+func (d *RuleDefaulter) clusterDefaults(ctx context.Context) (*oathkeeperv1alpha1.RuleDefaultsSpec, error) {
+	if !d.Cluster {
+		return nil, nil
+	}
+	var defaults oathkeeperv1alpha1.ClusterRuleDefaults
+	if err := d.Get(ctx, types.NamespacedName{Name: oathkeeperv1alpha1.DefaultsName}, &defaults); err != nil {
+		if apierrs.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &defaults.Spec, nil
+}
+
+// rulesHandler enqueues the Rules a RuleDefaults or ClusterRuleDefaults applies to, so they are validated and rendered again
+func (d *RuleDefaulter) rulesHandler() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []reconcile.Request {
+		if o.GetName() != oathkeeperv1alpha1.DefaultsName {
+			return nil
+		}
+		var opts []client.ListOption
+		if o.GetNamespace() != "" {
+			opts = append(opts, client.InNamespace(o.GetNamespace()))
+		}
+		rulesList, err := listRules(ctx, d.Reader, opts...)
+		if err != nil {
+			return nil
+		}
+		requests := make([]reconcile.Request, 0, len(rulesList.Items))
+		for _, rule := range rulesList.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: rule.Name, Namespace: rule.Namespace}})
+		}
+		return requests
+	})
+}
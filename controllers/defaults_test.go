@@ -0,0 +1,101 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRuleDefaulterApply(t *testing.T) {
+
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, oathkeeperv1alpha1.AddToScheme(scheme))
+
+	handler := func(name string) *oathkeeperv1alpha1.Handler {
+		return &oathkeeperv1alpha1.Handler{Name: name}
+	}
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&oathkeeperv1alpha1.ClusterRuleDefaults{
+			ObjectMeta: metav1.ObjectMeta{Name: oathkeeperv1alpha1.DefaultsName},
+			Spec: oathkeeperv1alpha1.RuleDefaultsSpec{
+				Authenticators: []*oathkeeperv1alpha1.Authenticator{{Handler: handler("anonymous")}},
+				Authorizer:     &oathkeeperv1alpha1.Authorizer{Handler: handler("allow")},
+				Upstream:       &oathkeeperv1alpha1.UpstreamDefaults{PreserveHost: boolPtr(true)},
+			},
+		},
+		&oathkeeperv1alpha1.RuleDefaults{
+			ObjectMeta: metav1.ObjectMeta{Name: oathkeeperv1alpha1.DefaultsName, Namespace: "team-a"},
+			Spec: oathkeeperv1alpha1.RuleDefaultsSpec{
+				Authenticators: []*oathkeeperv1alpha1.Authenticator{{Handler: handler("jwt")}},
+				Mutators:       []*oathkeeperv1alpha1.Mutator{{Handler: handler("header")}},
+			},
+		},
+	).Build()
+
+	t.Run("should prefer the Rule over its namespace over the cluster", func(t *testing.T) {
+
+		//given
+		plain := newValidRule("plain", "team-a")
+		explicit := newValidRule("explicit", "team-a")
+		explicit.Spec.Authenticators = []*oathkeeperv1alpha1.Authenticator{{Handler: handler("cookie_session")}}
+		other := newValidRule("other", "team-b")
+		rules := oathkeeperv1alpha1.RuleList{Items: []oathkeeperv1alpha1.Rule{plain, explicit, other}}
+
+		//when
+		actual, err := (&RuleDefaulter{Reader: reader, Cluster: true}).Apply(ctx, rules)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, "jwt", actual.Items[0].Spec.Authenticators[0].Name)
+		assert.Equal(t, "header", actual.Items[0].Spec.Mutators[0].Name)
+		assert.Equal(t, "allow", actual.Items[0].Spec.Authorizer.Name)
+		assert.True(t, *actual.Items[0].Spec.Upstream.PreserveHost)
+		assert.Equal(t, "cookie_session", actual.Items[1].Spec.Authenticators[0].Name)
+		assert.Equal(t, "anonymous", actual.Items[2].Spec.Authenticators[0].Name)
+		assert.Nil(t, actual.Items[2].Spec.Mutators)
+		assert.Nil(t, rules.Items[0].Spec.Authenticators, "Rules must not be changed")
+	})
+
+	t.Run("should leave out ClusterRuleDefaults when namespaced", func(t *testing.T) {
+
+		//when
+		actual, err := (&RuleDefaulter{Reader: reader}).ApplyTo(ctx, &oathkeeperv1alpha1.Rule{ObjectMeta: metav1.ObjectMeta{Name: "r1", Namespace: "team-b"}})
+
+		//then
+		require.NoError(t, err)
+		assert.Nil(t, actual.Spec.Authenticators)
+		assert.Equal(t, "unauthorized", actual.ToRuleJSON().Authenticators[0].Name)
+	})
+
+	t.Run("should render Rules with their defaults", func(t *testing.T) {
+
+		//given
+		rule := newValidRule("plain", "team-a")
+		reconciler, _ := newConfigMapReconciler(t, &rule, &oathkeeperv1alpha1.RuleDefaults{
+			ObjectMeta: metav1.ObjectMeta{Name: oathkeeperv1alpha1.DefaultsName, Namespace: "team-a"},
+			Spec:       oathkeeperv1alpha1.RuleDefaultsSpec{Authorizer: &oathkeeperv1alpha1.Authorizer{Handler: handler("remote_json")}},
+		}, newManagedConfigMap(defaultConfigMap, "[]"))
+		reconciler.Defaulter = &RuleDefaulter{Reader: reconciler.Client}
+
+		//when
+		_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: defaultConfigMap})
+
+		//then
+		require.NoError(t, err)
+		var actual apiv1.ConfigMap
+		require.NoError(t, reconciler.Get(ctx, defaultConfigMap, &actual))
+		assert.Contains(t, actual.Data["access-rules.json"], `"handler": "remote_json"`)
+	})
+}
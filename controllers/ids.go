@@ -0,0 +1,245 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// RuleIDScheme derives the IDs of Rules without spec.id from a template instead of their name and namespace
+type RuleIDScheme struct {
+	template *template.Template
+}
+
+// RuleIDData is what ID templates are executed with. The Name of the route of a RuleSet is <RuleSet>:<route>, and
+// ClusterRules have no Namespace.
+type RuleIDData struct {
+	Name      string
+	Namespace string
+	UID       string
+}
+
+// ParseRuleIDScheme parses an ID template such as "edge.{{ .Namespace }}.{{ .Name }}". An empty template keeps the
+// default IDs and returns nil.
+func ParseRuleIDScheme(text string) (*RuleIDScheme, error) {
+	if text == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New("rule-id").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	scheme := &RuleIDScheme{template: tmpl}
+	id, err := scheme.execute(RuleIDData{Name: "name", Namespace: "namespace", UID: "uid"})
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(id) == "" {
+		return nil, fmt.Errorf("rule ID template %q renders an empty ID", text)
+	}
+	return scheme, nil
+}
+
+func (s *RuleIDScheme) execute(data RuleIDData) (string, error) {
+	var out bytes.Buffer
+	if err := s.template.Execute(&out, data); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// Apply returns copies of the Rules with spec.id set from the template where it is missing. The template is checked
+// by ParseRuleIDScheme, a Rule it still fails on keeps its default ID.
+func (s *RuleIDScheme) Apply(rules oathkeeperv1alpha1.RuleList) oathkeeperv1alpha1.RuleList {
+	if s == nil {
+		return rules
+	}
+
+	rlCopy := rules
+	rlCopy.Items = make([]oathkeeperv1alpha1.Rule, 0, len(rules.Items))
+	for _, rule := range rules.Items {
+		rlCopy.Items = append(rlCopy.Items, *s.ApplyTo(&rule))
+	}
+	return rlCopy
+}
+
+// ApplyTo returns a copy of the Rule with spec.id set from the template if it is missing
+func (s *RuleIDScheme) ApplyTo(rule *oathkeeperv1alpha1.Rule) *oathkeeperv1alpha1.Rule {
+	if s == nil || rule.Spec.ID != nil {
+		return rule
+	}
+	id, err := s.execute(RuleIDData{Name: rule.Name, Namespace: rule.Namespace, UID: string(rule.UID)})
+	if err != nil || id == "" {
+		return rule
+	}
+	withID := rule.DeepCopy()
+	withID.Spec.ID = &id
+	return withID
+}
+
+// duplicateID reports an ID of the Rule that another Rule rendered into one of its targets renders to as well and
+// takes precedence. ClusterRules take precedence over Rules, otherwise the older Rule does. Like targetRules, the
+// default target renders every claimed Rule, whatever its configMapName, and the ClusterRules, so it holds the Rules
+// of every other target too.
+func (r *RuleReconciler) duplicateID(ctx context.Context, rule *oathkeeperv1alpha1.Rule) (invalid, err error) {
+	ids := map[string]bool{}
+	for _, id := range rule.IDs() {
+		ids[id] = true
+	}
+
+	others, err := listRules(ctx, r.Client)
+	if err != nil {
+		return nil, err
+	}
+	others, err = r.Claim.FilterNamespaces(ctx, r.Client, r.IDScheme.Apply(r.Claim.Filter(others)))
+	if err != nil {
+		return nil, err
+	}
+	clusterRules, err := r.listClusterRuleIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	others.Items = append(others.Items, clusterRules.Items...)
+
+	for _, other := range others.FilterDeleted().Items {
+		if other.Name == rule.Name && other.Namespace == rule.Namespace {
+			continue
+		}
+		if !precedes(other, *rule) {
+			continue
+		}
+		for _, id := range other.IDs() {
+			if ids[id] {
+				return fmt.Errorf("rule ID %s is already used by %s", id, describe(other)), nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// listClusterRuleIDs lists the claimed ClusterRules as Rules with their IDs, without resolving anything else
+func (r *RuleReconciler) listClusterRuleIDs(ctx context.Context) (oathkeeperv1alpha1.RuleList, error) {
+	if !r.ClusterRules {
+		return oathkeeperv1alpha1.RuleList{}, nil
+	}
+	var clusterRules oathkeeperv1alpha1.ClusterRuleList
+	if err := r.List(ctx, &clusterRules); err != nil {
+		return oathkeeperv1alpha1.RuleList{}, err
+	}
+	return r.IDScheme.Apply(r.Claim.Filter(clusterRules.ToRules())), nil
+}
+
+// duplicateIDHandler enqueues the Rules sharing an ID with a changed Rule, ClusterRule or RuleSet, so they are
+// validated again, e.g. once the Rule taking precedence is gone
+func (r *RuleReconciler) duplicateIDHandler() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []reconcile.Request {
+		var changed oathkeeperv1alpha1.RuleList
+		switch obj := o.(type) {
+		case *oathkeeperv1alpha1.Rule:
+			changed.Items = []oathkeeperv1alpha1.Rule{*obj}
+		case *oathkeeperv1alpha1.ClusterRule:
+			changed.Items = []oathkeeperv1alpha1.Rule{obj.ToRule()}
+		case *oathkeeperv1alpha1.RuleSet:
+			changed = obj.ToRules()
+		}
+		ids := map[string]bool{}
+		for _, rule := range r.IDScheme.Apply(changed).Items {
+			for _, id := range rule.IDs() {
+				ids[id] = true
+			}
+		}
+
+		rules, err := listRules(ctx, r.Client)
+		if err != nil {
+			return nil
+		}
+		clusterRules, err := r.listClusterRuleIDs(ctx)
+		if err != nil {
+			return nil
+		}
+		rules = r.IDScheme.Apply(r.Claim.Filter(rules))
+		rules.Items = append(rules.Items, clusterRules.Items...)
+
+		var requests []reconcile.Request
+		for _, rule := range rules.Items {
+			if rule.UID == o.GetUID() {
+				continue
+			}
+			for _, id := range rule.IDs() {
+				if ids[id] {
+					requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: rule.Name, Namespace: rule.Namespace}})
+					break
+				}
+			}
+		}
+		return requests
+	})
+}
+
+// withoutDuplicateIDs leaves out Rules rendering to an ID of a Rule taking precedence, in case their status is not up
+// to date yet
+func withoutDuplicateIDs(rules oathkeeperv1alpha1.RuleList) oathkeeperv1alpha1.RuleList {
+	sorted := append([]oathkeeperv1alpha1.Rule(nil), rules.Items...)
+	sort.SliceStable(sorted, func(i, j int) bool { return precedes(sorted[i], sorted[j]) })
+
+	seen := map[string]bool{}
+	duplicates := map[types.NamespacedName]bool{}
+	for _, rule := range sorted {
+		ids := rule.IDs()
+		if slices.ContainsFunc(ids, func(id string) bool { return seen[id] }) {
+			duplicates[types.NamespacedName{Name: rule.Name, Namespace: rule.Namespace}] = true
+			continue
+		}
+		for _, id := range ids {
+			seen[id] = true
+		}
+	}
+
+	rlCopy := rules
+	rlCopy.Items = make([]oathkeeperv1alpha1.Rule, 0, len(rules.Items))
+	for _, rule := range rules.Items {
+		if !duplicates[types.NamespacedName{Name: rule.Name, Namespace: rule.Namespace}] {
+			rlCopy.Items = append(rlCopy.Items, rule)
+		}
+	}
+	return rlCopy
+}
+
+// precedes tells whether a keeps an ID both Rules render to. ClusterRules, which have no namespace, precede Rules,
+// otherwise the older Rule does and ties are broken by namespace and name.
+func precedes(a, b oathkeeperv1alpha1.Rule) bool {
+	if (a.Namespace == "") != (b.Namespace == "") {
+		return a.Namespace == ""
+	}
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
+
+// describe names the object a Rule comes from in status messages
+func describe(rule oathkeeperv1alpha1.Rule) string {
+	if rule.Namespace == "" {
+		return "ClusterRule " + rule.Name
+	}
+	if ruleSet, route, ok := oathkeeperv1alpha1.ParseRouteRuleName(rule.Name); ok {
+		return fmt.Sprintf("route %s of RuleSet %s/%s", route, rule.Namespace, ruleSet)
+	}
+	return fmt.Sprintf("Rule %s/%s", rule.Namespace, rule.Name)
+}
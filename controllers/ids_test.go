@@ -0,0 +1,119 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParseRuleIDScheme(t *testing.T) {
+
+	t.Run("should keep the default IDs without a template", func(t *testing.T) {
+
+		//when
+		scheme, err := ParseRuleIDScheme("")
+
+		//then
+		require.NoError(t, err)
+		rule := newValidRule("r1", "team-a")
+		assert.Equal(t, "r1.team-a", scheme.ApplyTo(&rule).ToRuleJSON().ID)
+	})
+
+	t.Run("should derive IDs of Rules without spec.id from the template", func(t *testing.T) {
+
+		//given
+		scheme, err := ParseRuleIDScheme("edge.{{ .Namespace }}.{{ .Name }}")
+		require.NoError(t, err)
+		rule := newValidRule("r1", "team-a")
+		withID := newValidRule("r2", "team-a")
+		withID.Spec.ID = stringPtr("legacy")
+
+		//when
+		rules := scheme.Apply(oathkeeperv1alpha1.RuleList{Items: []oathkeeperv1alpha1.Rule{rule, withID}})
+
+		//then
+		assert.Equal(t, "edge.team-a.r1", rules.Items[0].ToRuleJSON().ID)
+		assert.Equal(t, "legacy", rules.Items[1].ToRuleJSON().ID)
+		assert.Nil(t, rule.Spec.ID, "the Rule must not be changed")
+	})
+
+	t.Run("should reject templates that can't render an ID", func(t *testing.T) {
+		for _, text := range []string{"{{ .Name", "{{ .Kind }}", "{{ if false }}x{{ end }}"} {
+			_, err := ParseRuleIDScheme(text)
+			assert.Error(t, err, text)
+		}
+	})
+}
+
+func TestDuplicateID(t *testing.T) {
+
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, oathkeeperv1alpha1.AddToScheme(scheme))
+
+	withID := func(name, namespace, id string, age time.Duration) oathkeeperv1alpha1.Rule {
+		rule := newValidRule(name, namespace)
+		rule.Spec.ID = stringPtr(id)
+		rule.CreationTimestamp = metav1.NewTime(time.Now().Add(-age).Truncate(time.Second))
+		return rule
+	}
+	older := withID("older", "team-a", "shared", time.Hour)
+	newer := withID("newer", "team-b", "shared", time.Minute)
+	elsewhere := withID("elsewhere", "team-b", "shared", 2*time.Hour)
+	elsewhere.Spec.ConfigMapName = stringPtr("other")
+	reconciler := &RuleReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(&older, &newer, &elsewhere).Build(),
+		Log:    logr.Discard(),
+	}
+
+	t.Run("should block the newer of two Rules of a target with the same ID", func(t *testing.T) {
+
+		//when
+		invalid, err := reconciler.duplicateID(ctx, &newer)
+
+		//then
+		require.NoError(t, err)
+		assert.EqualError(t, invalid, "rule ID shared is already used by Rule team-a/older")
+	})
+
+	t.Run("should block a Rule whose ID an older Rule with configMapName renders into the default target too", func(t *testing.T) {
+
+		//when
+		invalid, err := reconciler.duplicateID(ctx, &older)
+
+		//then
+		require.NoError(t, err)
+		assert.EqualError(t, invalid, "rule ID shared is already used by Rule team-b/elsewhere")
+	})
+
+	t.Run("should keep the oldest Rule", func(t *testing.T) {
+
+		//when
+		invalid, err := reconciler.duplicateID(ctx, &elsewhere)
+
+		//then
+		require.NoError(t, err)
+		assert.NoError(t, invalid)
+	})
+
+	t.Run("should leave out the newer Rule when rendering", func(t *testing.T) {
+
+		//when
+		rules := withoutDuplicateIDs(oathkeeperv1alpha1.RuleList{Items: []oathkeeperv1alpha1.Rule{newer, older}})
+
+		//then
+		require.Len(t, rules.Items, 1)
+		assert.Equal(t, "older", rules.Items[0].Name)
+	})
+}
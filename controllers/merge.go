@@ -0,0 +1,198 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	apiv1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// ManagedRuleIDsAnnotation lists the IDs of the rules maester wrote into a ConfigMap in merge mode before they were
+	// kept under the key returned by managedRuleIDsKey. It is only read, for ConfigMaps without that key.
+	ManagedRuleIDsAnnotation = "oathkeeper.ory.sh/managed-rule-ids"
+
+	// ReasonRuleIDConflict is the reason of Rule statuses when a foreign rule in their target has the same ID
+	ReasonRuleIDConflict = "RuleIDConflict"
+	// ReasonConfigMapNotMergeable is the reason of Rule statuses when the rules in their target can't be read in merge mode
+	ReasonConfigMapNotMergeable = "ConfigMapNotMergeable"
+)
+
+// ConflictError is returned in merge mode after a target was written without the Rules whose ID is already used by a foreign rule
+type ConflictError struct {
+	ConfigMap types.NamespacedName
+	IDs       []string
+	Rules     []types.NamespacedName
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("ConfigMap %s holds rules not written by oathkeeper-maester with the IDs %s, the Rules with the same IDs are left out",
+		e.ConfigMap, strings.Join(e.IDs, ", "))
+}
+
+// NotMergeableError is returned in merge mode when the rules in a target are not a JSON array of rules. Nothing is written.
+type NotMergeableError struct {
+	ConfigMap types.NamespacedName
+	Err       error
+}
+
+func (e *NotMergeableError) Error() string {
+	return fmt.Sprintf("rules in ConfigMap %s can't be merged: %s", e.ConfigMap, e.Err)
+}
+
+func (e *NotMergeableError) Unwrap() error {
+	return e.Err
+}
+
+// managedRuleIDsKey is the ConfigMap key that lists the IDs of the rules maester wrote in merge mode, one per line. All
+// other rules are foreign. Unlike an annotation it counts towards the size limits of the ConfigMap.
+func managedRuleIDsKey(rulesFileName string) string {
+	return strings.TrimSuffix(rulesFileName, filepath.Ext(rulesFileName)) + ".managed-rule-ids"
+}
+
+// foreignRules returns the rules in the target that maester didn't write, in their original order, along with their IDs.
+// A ConfigMap that lists no managed IDs yet, neither under managedRuleIDsKey nor in ManagedRuleIDsAnnotation, is assumed
+// to hold the rules of all current Rules. Rules with the ID of a baseline rule are never foreign, the baseline takes
+// their place.
+func (cmo *ConfigMapOperator) foreignRules(ctx context.Context, target types.NamespacedName, rules oathkeeperv1alpha1.RuleList) ([]json.RawMessage, map[string]bool, error) {
+
+	var configMap apiv1.ConfigMap
+	if err := cmo.getConfigMap(ctx, target, &configMap); err != nil {
+		if apierrs.IsNotFound(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	content := strings.TrimSpace(configMap.Data[cmo.RulesFileName])
+	if content == "" {
+		return nil, nil, nil
+	}
+
+	data, err := cmo.Format.Decode([]byte(content))
+	if err != nil {
+		return nil, nil, &NotMergeableError{ConfigMap: target, Err: err}
+	}
+	var existing []json.RawMessage
+	if err := json.Unmarshal(data, &existing); err != nil {
+		return nil, nil, &NotMergeableError{ConfigMap: target, Err: err}
+	}
+
+	managed := map[string]bool{}
+	for _, id := range cmo.Baseline.IDs() {
+		managed[id] = true
+	}
+	if ids, ok := configMap.Data[managedRuleIDsKey(cmo.RulesFileName)]; ok {
+		for _, id := range strings.Split(ids, "\n") {
+			managed[id] = true
+		}
+	} else if ids, ok := configMap.Annotations[ManagedRuleIDsAnnotation]; ok {
+		for _, id := range strings.Split(ids, ",") {
+			managed[id] = true
+		}
+	} else {
+		for _, rule := range rules.Items {
+			for _, id := range rule.IDs() {
+				managed[id] = true
+			}
+		}
+	}
+
+	var foreign []json.RawMessage
+	foreignIDs := map[string]bool{}
+	for _, item := range existing {
+		var rule struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(item, &rule); err != nil {
+			return nil, nil, &NotMergeableError{ConfigMap: target, Err: err}
+		}
+		if managed[rule.ID] {
+			continue
+		}
+		foreign = append(foreign, item)
+		foreignIDs[rule.ID] = true
+	}
+	return foreign, foreignIDs, nil
+}
+
+// withoutConflicts splits off the Rules whose ID is used by a foreign rule
+func withoutConflicts(rules oathkeeperv1alpha1.RuleList, foreignIDs map[string]bool) (oathkeeperv1alpha1.RuleList, *ConflictError) {
+	rlCopy := rules
+	rlCopy.Items = []oathkeeperv1alpha1.Rule{}
+	var conflict ConflictError
+	for _, rule := range rules.Items {
+		conflicting := false
+		for _, id := range rule.IDs() {
+			if foreignIDs[id] {
+				conflict.IDs = append(conflict.IDs, id)
+				conflicting = true
+			}
+		}
+		if conflicting {
+			conflict.Rules = append(conflict.Rules, types.NamespacedName{Name: rule.Name, Namespace: rule.Namespace})
+			continue
+		}
+		rlCopy.Items = append(rlCopy.Items, rule)
+	}
+	if len(conflict.Rules) == 0 {
+		return rlCopy, nil
+	}
+	sort.Strings(conflict.IDs)
+	return rlCopy, &conflict
+}
+
+// ruleIDs returns the sorted IDs of the rules rendered from the Rules and the baseline, as stored under managedRuleIDsKey
+func ruleIDs(rules oathkeeperv1alpha1.RuleList, baseline *oathkeeperv1alpha1.Baseline) string {
+	ids := baseline.IDs()
+	for _, rule := range rules.Items {
+		for _, id := range rule.IDs() {
+			if !baseline.Reserves(id) {
+				ids = append(ids, id)
+			}
+		}
+	}
+	sort.Strings(ids)
+	return strings.Join(ids, "\n")
+}
+
+// mergeRules appends the foreign rules to the rendered ones, both in the given format
+func mergeRules(rendered string, foreign []json.RawMessage, format oathkeeperv1alpha1.OutputFormat) (string, error) {
+	if len(foreign) == 0 {
+		return rendered, nil
+	}
+
+	data, err := format.Decode([]byte(rendered))
+	if err != nil {
+		return "", err
+	}
+	var merged []json.RawMessage
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return "", err
+	}
+	merged = append(merged, foreign...)
+
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(merged); err != nil {
+		return "", err
+	}
+	encoded, err := format.Encode(bytes.TrimSuffix(b.Bytes(), []byte("\n")))
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
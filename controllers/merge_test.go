@@ -0,0 +1,175 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/yaml"
+)
+
+func TestConfigMapOperatorMerge(t *testing.T) {
+
+	ctx := context.Background()
+	rules := oathkeeperv1alpha1.RuleList{Items: []oathkeeperv1alpha1.Rule{newValidRule("r1", "default")}}
+	foreign := `[{"id": "hand-made", "match": {"url": "http://legacy/<.*>", "methods": ["GET"]}}]`
+
+	newMergeReconciler := func(t *testing.T, content string) *ConfigMapReconciler {
+		configMap := newManagedConfigMap(defaultConfigMap, content)
+		configMap.Data[managedRuleIDsKey("access-rules.json")] = ""
+		reconciler, _ := newConfigMapReconciler(t, configMap)
+		reconciler.Merge = true
+		return reconciler
+	}
+
+	t.Run("should keep foreign rules next to the rendered ones", func(t *testing.T) {
+
+		//given
+		reconciler := newMergeReconciler(t, foreign)
+
+		//when
+		require.NoError(t, reconciler.CreateOrUpdate(ctx, rules, nil))
+
+		//then
+		var actual apiv1.ConfigMap
+		require.NoError(t, reconciler.Get(ctx, defaultConfigMap, &actual))
+		assert.Equal(t, []string{"r1.default", "hand-made"}, idsOf(t, actual.Data["access-rules.json"]))
+		assert.Contains(t, actual.Data["access-rules.json"], "http://legacy/<.*>")
+		assert.Equal(t, "r1.default", actual.Data[managedRuleIDsKey("access-rules.json")])
+	})
+
+	t.Run("should take over the managed IDs from the annotation of earlier versions", func(t *testing.T) {
+
+		//given
+		configMap := newManagedConfigMap(defaultConfigMap, `[{"id": "old.default"}, {"id": "hand-made"}]`)
+		configMap.Annotations = map[string]string{ManagedRuleIDsAnnotation: "old.default"}
+		reconciler, _ := newConfigMapReconciler(t, configMap)
+		reconciler.Merge = true
+
+		//when
+		require.NoError(t, reconciler.CreateOrUpdate(ctx, rules, nil))
+
+		//then
+		var actual apiv1.ConfigMap
+		require.NoError(t, reconciler.Get(ctx, defaultConfigMap, &actual))
+		assert.Equal(t, []string{"r1.default", "hand-made"}, idsOf(t, actual.Data["access-rules.json"]))
+		assert.Equal(t, "r1.default", actual.Data[managedRuleIDsKey("access-rules.json")])
+		assert.NotContains(t, actual.Annotations, ManagedRuleIDsAnnotation)
+	})
+
+	t.Run("should drop rules it wrote before once their Rule is gone", func(t *testing.T) {
+
+		//given
+		reconciler := newMergeReconciler(t, foreign)
+		require.NoError(t, reconciler.CreateOrUpdate(ctx, rules, nil))
+
+		//when
+		require.NoError(t, reconciler.CreateOrUpdate(ctx, oathkeeperv1alpha1.RuleList{Items: []oathkeeperv1alpha1.Rule{}}, nil))
+
+		//then
+		var actual apiv1.ConfigMap
+		require.NoError(t, reconciler.Get(ctx, defaultConfigMap, &actual))
+		assert.Equal(t, []string{"hand-made"}, idsOf(t, actual.Data["access-rules.json"]))
+	})
+
+	t.Run("should leave out Rules whose ID is used by a foreign rule", func(t *testing.T) {
+
+		//given
+		reconciler := newMergeReconciler(t, `[{"id": "r1.default"}]`)
+
+		//when
+		err := reconciler.CreateOrUpdate(ctx, rules, nil)
+
+		//then
+		var conflict *ConflictError
+		require.True(t, errors.As(err, &conflict))
+		assert.Equal(t, []string{"r1.default"}, conflict.IDs)
+		assert.Equal(t, []types.NamespacedName{{Name: "r1", Namespace: "default"}}, conflict.Rules)
+
+		var actual apiv1.ConfigMap
+		require.NoError(t, reconciler.Get(ctx, defaultConfigMap, &actual))
+		assert.JSONEq(t, `[{"id": "r1.default"}]`, actual.Data["access-rules.json"])
+	})
+
+	t.Run("should refuse to merge into content that is not a list of rules", func(t *testing.T) {
+
+		//given
+		reconciler := newMergeReconciler(t, "not json")
+
+		//when
+		err := reconciler.CreateOrUpdate(ctx, rules, nil)
+
+		//then
+		var notMergeable *NotMergeableError
+		require.True(t, errors.As(err, &notMergeable))
+	})
+
+	t.Run("should merge rules written as YAML", func(t *testing.T) {
+
+		//given
+		configMap := newManagedConfigMap(defaultConfigMap, "")
+		configMap.Data = map[string]string{"access-rules.yaml": "- id: hand-made\n  match:\n    url: http://legacy/<.*>\n    methods: [GET]\n"}
+		configMap.Data[managedRuleIDsKey("access-rules.json")] = ""
+		reconciler, _ := newConfigMapReconciler(t, configMap)
+		reconciler.Merge = true
+		reconciler.RulesFileName = "access-rules.yaml"
+		reconciler.Format = oathkeeperv1alpha1.FormatYAML
+
+		//when
+		require.NoError(t, reconciler.CreateOrUpdate(ctx, rules, nil))
+
+		//then
+		var actual apiv1.ConfigMap
+		require.NoError(t, reconciler.Get(ctx, defaultConfigMap, &actual))
+		content, err := yaml.YAMLToJSON([]byte(actual.Data["access-rules.yaml"]))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"r1.default", "hand-made"}, idsOf(t, string(content)))
+		assert.Contains(t, actual.Data["access-rules.yaml"], "url: http://legacy/<.*>")
+	})
+
+	t.Run("should not report edits of foreign rules as drift", func(t *testing.T) {
+
+		//given
+		rule := rules.Items[0]
+		reconciler := newMergeReconciler(t, foreign)
+		require.NoError(t, reconciler.Create(ctx, &rule))
+		require.NoError(t, reconciler.CreateOrUpdate(ctx, rules, nil))
+
+		var configMap apiv1.ConfigMap
+		require.NoError(t, reconciler.Get(ctx, defaultConfigMap, &configMap))
+		configMap.Data["access-rules.json"] = `[{"id": "r1.default"}, {"id": "hand-made", "upstream": {"url": "http://new"}}]`
+		require.NoError(t, reconciler.Update(ctx, &configMap))
+
+		//when
+		_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: defaultConfigMap})
+
+		//then
+		require.NoError(t, err)
+		require.NoError(t, reconciler.Get(ctx, defaultConfigMap, &configMap))
+		assert.Equal(t, []string{"r1.default", "hand-made"}, idsOf(t, configMap.Data["access-rules.json"]))
+		assert.Contains(t, configMap.Data["access-rules.json"], "http://new")
+		assert.Contains(t, configMap.Data["access-rules.json"], "http://my-app/r1")
+	})
+}
+
+func idsOf(t *testing.T, content string) []string {
+	var rules []struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(content), &rules))
+	ids := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		ids = append(ids, rule.ID)
+	}
+	return ids
+}
@@ -0,0 +1,30 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	configMapSizeBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "oathkeeper_maester_configmap_size_bytes",
+		Help: "Size of the rules rendered into a ConfigMap",
+	}, []string{"namespace", "configmap"})
+
+	configMapSizeExceeded = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "oathkeeper_maester_configmap_size_exceeded_total",
+		Help: "Number of times the rules rendered into a ConfigMap exceeded the soft threshold or the hard limit",
+	}, []string{"namespace", "configmap", "limit"})
+
+	unclaimedRules = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "oathkeeper_maester_unclaimed_rules",
+		Help: "Number of Rules, RuleSets and ClusterRules claimed by no instance of oathkeeper-maester",
+	}, []string{"kind"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(configMapSizeBytes, configMapSizeExceeded, unclaimedRules)
+}
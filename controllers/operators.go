@@ -6,49 +6,119 @@ package controllers
 import (
 	"bufio"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/avast/retry-go"
 	"github.com/go-logr/logr"
 	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	"github.com/ory/oathkeeper-maester/internal/validation"
 	apiv1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	// ManagedByLabel is stamped on every ConfigMap maester writes to, so they can be watched for drift
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	// ManagedByValue is the value of ManagedByLabel on ConfigMaps written by maester
+	ManagedByValue = "oathkeeper-maester"
+	// ContentHashAnnotation holds the hash of the rendered rules last written to a ConfigMap
+	ContentHashAnnotation = "oathkeeper.ory.sh/content-hash"
+	// VersionAnnotation holds the version of maester that last wrote to a ConfigMap
+	VersionAnnotation = "oathkeeper.ory.sh/maester-version"
+	// GeneratedAtAnnotation holds the time the rendered rules were last written to a ConfigMap
+	GeneratedAtAnnotation = "oathkeeper.ory.sh/generated-at"
+	// ShardOfLabel is stamped on shard ConfigMaps and holds the name of the target they belong to
+	ShardOfLabel = "oathkeeper.ory.sh/shard-of"
+)
+
 // OperatorMode is an interface that provides runtime strategy for operating mode ("controller" or "sidecar").
 type OperatorMode interface {
 	// CreateOrUpdate ORY Oathkeeper Access Rule list using implementation-specific means.
-	// oathkeeperRulesJSON - serialized JSON with an array of objects that conform to Oathkeeper Rule syntax
+	// rules - the valid rules of the target, rendered by the implementation into objects that conform to Oathkeeper Rule syntax
 	// triggeredBy - the recently created/update rule that triggered the operation
-	CreateOrUpdate(ctx context.Context, oathkeeperRulesJSON []byte, triggeredBy *oathkeeperv1alpha1.Rule) error
+	CreateOrUpdate(ctx context.Context, rules oathkeeperv1alpha1.RuleList, triggeredBy *oathkeeperv1alpha1.Rule) error
 }
 
-// ConfigMapOperator that maintains Oathkeeper rules as an json-formatted entry in a ConfigMap
+// ConfigMapOperator that maintains Oathkeeper rules as a json- or yaml-formatted entry in a ConfigMap
 type ConfigMapOperator struct {
 	client.Client
+	// APIReader reads ConfigMaps past the cache, which only holds the ConfigMaps labelled ManagedByLabel. The client
+	// is used when it is unset.
+	APIReader        client.Reader
 	Log              logr.Logger
 	DefaultConfigMap types.NamespacedName
 	RulesFileName    string
+	Version          string
+	Sharding         Sharding
+	SizeLimits       SizeLimits
+	// Merge keeps rules in the target that maester didn't write, see ManagedRuleIDsAnnotation
+	Merge bool
+	// AdoptConfigMaps writes into unlabelled ConfigMaps created by older versions of maester, see adoptable
+	AdoptConfigMaps bool
+	Recorder        events.EventRecorder
+	// Baseline holds static rules rendered into every target next to the Rules
+	Baseline *oathkeeperv1alpha1.Baseline
+	// DecisionOnly are the targets the baseline rules are rendered into without upstream
+	DecisionOnly *DecisionOnlyTargets
+	// ValidationConfig describes the target Oathkeeper: the fields its profile reads and the deprecated handlers
+	// that are rewritten
+	ValidationConfig validation.Config
+	// Format of the rendered rules, JSON unless set
+	Format oathkeeperv1alpha1.OutputFormat
 }
 
-// FilesOperator that maintains Oathkeeper rules as a flat json file in a local filesystem
+// FilesOperator that maintains Oathkeeper rules as a flat json or yaml file in a local filesystem
 type FilesOperator struct {
 	Log           logr.Logger
 	RulesFilePath string
+	// Baseline holds static rules rendered into the file next to the Rules
+	Baseline *oathkeeperv1alpha1.Baseline
+	// DecisionOnly renders the baseline rules without upstream when it covers all targets
+	DecisionOnly *DecisionOnlyTargets
+	// ValidationConfig describes the target Oathkeeper: the fields its profile reads and the deprecated handlers
+	// that are rewritten
+	ValidationConfig validation.Config
+	// Format of the rendered rules, JSON unless set
+	Format oathkeeperv1alpha1.OutputFormat
 }
 
-func (cmo *ConfigMapOperator) updateOrCreateRulesConfigmap(ctx context.Context, configMap types.NamespacedName, data string) error {
+// rendering is what the rules of a target are rendered into
+type rendering struct {
+	// rules are the Rules that are rendered, without conflicting ones
+	rules      oathkeeperv1alpha1.RuleList
+	configMaps map[types.NamespacedName]map[string]string
+	conflict   *ConflictError
+	// foreign are the rules in the target that maester didn't write, kept in merge mode
+	foreign []json.RawMessage
+}
+
+// ShardIndexEntry describes one shard in the index written next to sharded rules
+type ShardIndexEntry struct {
+	ConfigMap string `json:"configMap"`
+	Key       string `json:"key"`
+	Rules     int    `json:"rules"`
+}
+
+func (cmo *ConfigMapOperator) updateOrCreateRulesConfigmap(ctx context.Context, configMap types.NamespacedName, data map[string]string, labels map[string]string) error {
 
 	var oathkeeperRulesConfigmap apiv1.ConfigMap
 	var exists = false
 
 	fetchMapFunc := func() error {
 
-		if err := cmo.Get(ctx, configMap, &oathkeeperRulesConfigmap); err != nil {
+		if err := cmo.getConfigMap(ctx, configMap, &oathkeeperRulesConfigmap); err != nil {
 
 			if apierrs.IsForbidden(err) {
 				return retry.Unrecoverable(err)
@@ -65,21 +135,59 @@ func (cmo *ConfigMapOperator) updateOrCreateRulesConfigmap(ctx context.Context,
 		return nil
 	}
 
+	hash := dataHash(data)
+
 	createMapFunc := func() error {
-		cmo.Log.Info("creating ConfigMap")
+		cmo.Log.Info(fmt.Sprintf("creating ConfigMap %s", configMap))
 		oathkeeperRulesConfigmap = apiv1.ConfigMap{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      configMap.Name,
-				Namespace: configMap.Namespace,
+				Name:        configMap.Name,
+				Namespace:   configMap.Namespace,
+				Labels:      map[string]string{ManagedByLabel: ManagedByValue},
+				Annotations: cmo.annotations(hash, data),
 			},
-			Data: map[string]string{cmo.RulesFileName: data},
+			Data: data,
+		}
+		for k, v := range labels {
+			oathkeeperRulesConfigmap.Labels[k] = v
 		}
 		return cmo.Create(ctx, &oathkeeperRulesConfigmap)
 	}
 
+	// the stored data is hashed as well, so a ConfigMap edited by hand is never mistaken for an up to date one
+	upToDateFunc := func() bool {
+		return oathkeeperRulesConfigmap.Annotations[ContentHashAnnotation] == hash &&
+			oathkeeperRulesConfigmap.Annotations[ManagedKeysAnnotation] == managedKeys(data) &&
+			dataHash(managedData(&oathkeeperRulesConfigmap, data)) == hash
+	}
+
+	// only keys written by maester are replaced, keys it wrote before but doesn't anymore are removed
 	updateMapFunc := func() error {
-		cmo.Log.Info("updating ConfigMap")
-		oathkeeperRulesConfigmap.Data = map[string]string{cmo.RulesFileName: data}
+		cmo.Log.Info(fmt.Sprintf("updating ConfigMap %s", configMap))
+		if oathkeeperRulesConfigmap.Data == nil {
+			oathkeeperRulesConfigmap.Data = map[string]string{}
+		}
+		for k := range managedData(&oathkeeperRulesConfigmap, nil) {
+			delete(oathkeeperRulesConfigmap.Data, k)
+		}
+		for k, v := range data {
+			oathkeeperRulesConfigmap.Data[k] = v
+		}
+		if oathkeeperRulesConfigmap.Labels == nil {
+			oathkeeperRulesConfigmap.Labels = map[string]string{}
+		}
+		oathkeeperRulesConfigmap.Labels[ManagedByLabel] = ManagedByValue
+		for k, v := range labels {
+			oathkeeperRulesConfigmap.Labels[k] = v
+		}
+		if oathkeeperRulesConfigmap.Annotations == nil {
+			oathkeeperRulesConfigmap.Annotations = map[string]string{}
+		}
+		for k, v := range cmo.annotations(hash, data) {
+			oathkeeperRulesConfigmap.Annotations[k] = v
+		}
+		// the managed rule IDs are kept under managedRuleIDsKey now
+		delete(oathkeeperRulesConfigmap.Annotations, ManagedRuleIDsAnnotation)
 		err := cmo.Update(ctx, &oathkeeperRulesConfigmap)
 		return err
 	}
@@ -92,6 +200,10 @@ func (cmo *ConfigMapOperator) updateOrCreateRulesConfigmap(ctx context.Context,
 		}
 
 		if exists {
+			if upToDateFunc() {
+				cmo.Log.Info(fmt.Sprintf("ConfigMap %s is up to date, skipping update", configMap))
+				return nil
+			}
 			err := updateMapFunc()
 			if err != nil {
 				if isObjectHasBeenModified(err) {
@@ -105,19 +217,241 @@ func (cmo *ConfigMapOperator) updateOrCreateRulesConfigmap(ctx context.Context,
 	})
 }
 
-func (cmo *ConfigMapOperator) CreateOrUpdate(ctx context.Context, oathkeeperRulesJSON []byte, triggeredBy *oathkeeperv1alpha1.Rule) error {
+func (cmo *ConfigMapOperator) annotations(hash string, data map[string]string) map[string]string {
+	return map[string]string{
+		ContentHashAnnotation: hash,
+		ManagedKeysAnnotation: managedKeys(data),
+		VersionAnnotation:     cmo.Version,
+		GeneratedAtAnnotation: time.Now().UTC().Format(time.RFC3339),
+	}
+}
 
-	configMapRef := cmo.DefaultConfigMap
+// getConfigMap reads a ConfigMap that may not be labelled ManagedByLabel yet, and so may be missing from the cache
+func (cmo *ConfigMapOperator) getConfigMap(ctx context.Context, ref types.NamespacedName, configMap *apiv1.ConfigMap) error {
+	if cmo.APIReader != nil {
+		return cmo.APIReader.Get(ctx, ref, configMap)
+	}
+	return cmo.Get(ctx, ref, configMap)
+}
+
+// target returns the ConfigMap the rules of the given Rule are rendered into
+func (cmo *ConfigMapOperator) target(triggeredBy *oathkeeperv1alpha1.Rule) types.NamespacedName {
 	if triggeredBy != nil && triggeredBy.Spec.ConfigMapName != nil && len(*triggeredBy.Spec.ConfigMapName) > 0 {
-		configMapRef = types.NamespacedName{
+		return types.NamespacedName{
 			Name:      *triggeredBy.Spec.ConfigMapName,
 			Namespace: triggeredBy.ObjectMeta.Namespace,
 		}
 	}
-	return cmo.updateOrCreateRulesConfigmap(ctx, configMapRef, string(oathkeeperRulesJSON))
+	return cmo.DefaultConfigMap
+}
+
+// desiredConfigMaps renders the rules of a target into the data of every ConfigMap the target consists of.
+// Without sharding that is the target alone, otherwise the target holds the shard index and, depending on the layout,
+// the shards themselves or references to ConfigMaps named after it. Baseline rules are rendered into the first or last shard
+// according to their placement, so that Oathkeeper loads them once.
+func (cmo *ConfigMapOperator) desiredConfigMaps(target types.NamespacedName, rules oathkeeperv1alpha1.RuleList) (map[types.NamespacedName]map[string]string, error) {
+
+	targetBaseline, err := cmo.DecisionOnly.Baseline(cmo.Baseline, target)
+	if err != nil {
+		return nil, err
+	}
+
+	if !cmo.Sharding.Enabled() {
+		data, err := cmo.renderRules(rules, targetBaseline)
+		if err != nil {
+			return nil, err
+		}
+		return map[types.NamespacedName]map[string]string{target: {cmo.RulesFileName: string(data)}}, nil
+	}
+
+	shards, err := cmo.Sharding.split(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	baselineShard := -1
+	if targetBaseline != nil && len(targetBaseline.Rules) > 0 {
+		if len(shards) == 0 {
+			shards = []shard{{name: "baseline"}}
+		}
+		baselineShard = len(shards) - 1
+		if targetBaseline.Placement == oathkeeperv1alpha1.BaselineFirst {
+			baselineShard = 0
+		}
+	}
+
+	desired := map[types.NamespacedName]map[string]string{target: {}}
+	index := make([]ShardIndexEntry, 0, len(shards))
+	for i, s := range shards {
+		var baseline *oathkeeperv1alpha1.Baseline
+		if i == baselineShard {
+			baseline = targetBaseline
+		}
+		data, err := cmo.renderRules(s.rules, baseline)
+		if err != nil {
+			return nil, err
+		}
+
+		entry := ShardIndexEntry{Rules: len(s.rules.Items) + len(baseline.IDs())}
+		if cmo.Sharding.Layout == ShardingLayoutConfigMaps {
+			ref := types.NamespacedName{Name: target.Name + "-" + s.name, Namespace: target.Namespace}
+			desired[ref] = map[string]string{cmo.RulesFileName: string(data)}
+			entry.ConfigMap, entry.Key = ref.Name, cmo.RulesFileName
+		} else {
+			key := shardKey(cmo.RulesFileName, s.name)
+			desired[target][key] = string(data)
+			entry.ConfigMap, entry.Key = target.Name, key
+		}
+		index = append(index, entry)
+	}
+
+	indexJSON, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	desired[target][indexKey(cmo.RulesFileName)] = string(indexJSON)
+
+	return desired, nil
+}
+
+// renderRules renders the rules of one ConfigMap key in the format of the operator
+func (cmo *ConfigMapOperator) renderRules(rules oathkeeperv1alpha1.RuleList, baseline *oathkeeperv1alpha1.Baseline) ([]byte, error) {
+	data, err := rules.ToOathkeeperRulesFor(cmo.ValidationConfig, baseline)
+	if err != nil {
+		return nil, err
+	}
+	return cmo.Format.Encode(data)
+}
+
+// render renders the rules of a target. In merge mode the rules in the target that maester didn't write are kept
+// and Rules whose ID is used by one of them are left out.
+func (cmo *ConfigMapOperator) render(ctx context.Context, target types.NamespacedName, rules oathkeeperv1alpha1.RuleList) (*rendering, error) {
+
+	r := &rendering{rules: rules}
+
+	if cmo.Merge {
+		var foreignIDs map[string]bool
+		var err error
+		r.foreign, foreignIDs, err = cmo.foreignRules(ctx, target, rules)
+		if err != nil {
+			return nil, err
+		}
+		r.rules, r.conflict = withoutConflicts(rules, foreignIDs)
+		if r.conflict != nil {
+			r.conflict.ConfigMap = target
+		}
+	}
+
+	desired, err := cmo.mergedConfigMaps(target, r.rules, r.foreign)
+	if err != nil {
+		return nil, err
+	}
+
+	r.configMaps = desired
+	return r, nil
+}
+
+// mergedConfigMaps renders the ConfigMaps of a target with the foreign rules appended to its rules and the IDs of the
+// rules maester wrote listed next to them
+func (cmo *ConfigMapOperator) mergedConfigMaps(target types.NamespacedName, rules oathkeeperv1alpha1.RuleList, foreign []json.RawMessage) (map[types.NamespacedName]map[string]string, error) {
+	desired, err := cmo.desiredConfigMaps(target, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmo.Merge {
+		merged, err := mergeRules(desired[target][cmo.RulesFileName], foreign, cmo.Format)
+		if err != nil {
+			return nil, err
+		}
+		desired[target][cmo.RulesFileName] = merged
+		desired[target][managedRuleIDsKey(cmo.RulesFileName)] = ruleIDs(rules, cmo.Baseline)
+	}
+	return desired, nil
+}
+
+// apply writes the desired ConfigMaps of a target and deletes shards of it that are no longer needed
+func (cmo *ConfigMapOperator) apply(ctx context.Context, target types.NamespacedName, desired map[types.NamespacedName]map[string]string) error {
+
+	refs := make([]types.NamespacedName, 0, len(desired))
+	for ref := range desired {
+		refs = append(refs, ref)
+	}
+	sort.Slice(refs, func(i, j int) bool { return refs[i].String() < refs[j].String() })
+
+	// the default ConfigMap is configured by the operator of maester, every other one has to be owned before anything is written
+	for _, ref := range refs {
+		if ref == cmo.DefaultConfigMap {
+			continue
+		}
+		var existing apiv1.ConfigMap
+		if err := cmo.getConfigMap(ctx, ref, &existing); err != nil {
+			if apierrs.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if !writable(&existing) {
+			if !cmo.AdoptConfigMaps || !cmo.adoptable(&existing) {
+				return &NotOwnedError{ConfigMap: ref}
+			}
+			cmo.Log.Info(fmt.Sprintf("adopting ConfigMap %s created by an older version of oathkeeper-maester", ref))
+		}
+	}
+
+	for _, ref := range refs {
+		var labels map[string]string
+		if ref != target {
+			labels = map[string]string{ShardOfLabel: target.Name}
+		}
+		if err := cmo.updateOrCreateRulesConfigmap(ctx, ref, desired[ref], labels); err != nil {
+			return err
+		}
+	}
+
+	var shards apiv1.ConfigMapList
+	if err := cmo.List(ctx, &shards, client.InNamespace(target.Namespace), client.MatchingLabels{ManagedByLabel: ManagedByValue, ShardOfLabel: target.Name}); err != nil {
+		return err
+	}
+	for i := range shards.Items {
+		shardConfigMap := &shards.Items[i]
+		if _, ok := desired[client.ObjectKeyFromObject(shardConfigMap)]; ok {
+			continue
+		}
+		cmo.Log.Info(fmt.Sprintf("deleting stale shard ConfigMap %s/%s", shardConfigMap.Namespace, shardConfigMap.Name))
+		if err := cmo.Delete(ctx, shardConfigMap); err != nil && !apierrs.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cmo *ConfigMapOperator) CreateOrUpdate(ctx context.Context, rules oathkeeperv1alpha1.RuleList, triggeredBy *oathkeeperv1alpha1.Rule) error {
+
+	configMapRef := cmo.target(triggeredBy)
+	rendered, err := cmo.render(ctx, configMapRef, rules)
+	if err != nil {
+		return err
+	}
+	if err := cmo.checkSize(configMapRef, rendered, triggeredBy); err != nil {
+		return err
+	}
+	if err := cmo.apply(ctx, configMapRef, rendered.configMaps); err != nil {
+		return err
+	}
+	if rendered.conflict != nil {
+		return rendered.conflict
+	}
+	return nil
 }
 
 func (fo *FilesOperator) updateOrCreateRulesFile(ctx context.Context, data string) error {
+	if current, err := os.ReadFile(fo.RulesFilePath); err == nil && contentHash(current) == contentHash([]byte(data)) {
+		fo.Log.Info(fmt.Sprintf("%s is up to date, skipping write", fo.RulesFilePath))
+		return nil
+	}
+
 	var f *os.File
 	f, err := os.Create(fo.RulesFilePath)
 	if err != nil {
@@ -136,10 +470,57 @@ func (fo *FilesOperator) updateOrCreateRulesFile(ctx context.Context, data strin
 	return nil
 }
 
-func (fo *FilesOperator) CreateOrUpdate(ctx context.Context, oathkeeperRulesJSON []byte, triggeredBy *oathkeeperv1alpha1.Rule) error {
+func (fo *FilesOperator) CreateOrUpdate(ctx context.Context, rules oathkeeperv1alpha1.RuleList, triggeredBy *oathkeeperv1alpha1.Rule) error {
 	if triggeredBy != nil && triggeredBy.Spec.ConfigMapName != nil && len(*triggeredBy.Spec.ConfigMapName) > 0 {
 		fo.Log.Info("Ignoring Spec.ConfigMapName value - sidecar mode enabled")
 	}
 
-	return fo.updateOrCreateRulesFile(ctx, string(oathkeeperRulesJSON))
+	baseline, err := fo.DecisionOnly.Baseline(fo.Baseline, types.NamespacedName{})
+	if err != nil {
+		return err
+	}
+	oathkeeperRulesJSON, err := rules.ToOathkeeperRulesFor(fo.ValidationConfig, baseline)
+	if err != nil {
+		return err
+	}
+	data, err := fo.Format.Encode(oathkeeperRulesJSON)
+	if err != nil {
+		return err
+	}
+
+	return fo.updateOrCreateRulesFile(ctx, string(data))
+}
+
+func contentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// dataHash hashes the keys and values of ConfigMap data in a stable order
+func dataHash(data map[string]string) string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		h.Write([]byte(k))
+		h.Write([]byte{0})
+		h.Write([]byte(data[k]))
+		h.Write([]byte{0})
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil))
+}
+
+// shardKey returns the ConfigMap key of a shard, e.g. access-rules-0.json for access-rules.json
+func shardKey(rulesFileName, shard string) string {
+	ext := filepath.Ext(rulesFileName)
+	return strings.TrimSuffix(rulesFileName, ext) + "-" + shard + ext
+}
+
+// indexKey returns the ConfigMap key of the shard index, e.g. access-rules.index.json for access-rules.json
+func indexKey(rulesFileName string) string {
+	return strings.TrimSuffix(rulesFileName, filepath.Ext(rulesFileName)) + ".index.json"
 }
@@ -0,0 +1,137 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var noRules = oathkeeperv1alpha1.RuleList{Items: []oathkeeperv1alpha1.Rule{}}
+
+func TestConfigMapOperatorCreateOrUpdate(t *testing.T) {
+
+	ctx := context.Background()
+
+	t.Run("should stamp annotations and skip writes of unchanged content", func(t *testing.T) {
+
+		//given
+		reconciler, _ := newConfigMapReconciler(t)
+		operator := reconciler.ConfigMapOperator
+		operator.Version = "v1.2.3"
+		require.NoError(t, operator.CreateOrUpdate(ctx, noRules, nil))
+
+		var before apiv1.ConfigMap
+		require.NoError(t, operator.Get(ctx, defaultConfigMap, &before))
+
+		//when
+		require.NoError(t, operator.CreateOrUpdate(ctx, noRules, nil))
+
+		//then
+		var after apiv1.ConfigMap
+		require.NoError(t, operator.Get(ctx, defaultConfigMap, &after))
+		assert.Equal(t, before.ResourceVersion, after.ResourceVersion)
+		assert.Equal(t, dataHash(map[string]string{"access-rules.json": "[]"}), after.Annotations[ContentHashAnnotation])
+		assert.Equal(t, "v1.2.3", after.Annotations[VersionAnnotation])
+		assert.NotEmpty(t, after.Annotations[GeneratedAtAnnotation])
+	})
+
+	t.Run("should not trust the hash of a ConfigMap edited by hand", func(t *testing.T) {
+
+		//given
+		configMap := newManagedConfigMap(defaultConfigMap, "edited")
+		configMap.Annotations = map[string]string{ContentHashAnnotation: dataHash(map[string]string{"access-rules.json": "[]"})}
+		reconciler, _ := newConfigMapReconciler(t, configMap)
+		operator := reconciler.ConfigMapOperator
+
+		//when
+		require.NoError(t, operator.CreateOrUpdate(ctx, noRules, nil))
+
+		//then
+		var after apiv1.ConfigMap
+		require.NoError(t, operator.Get(ctx, defaultConfigMap, &after))
+		assert.Equal(t, "[]", after.Data["access-rules.json"])
+	})
+}
+
+func TestFilesOperatorCreateOrUpdate(t *testing.T) {
+
+	t.Run("should not rewrite a file with unchanged content", func(t *testing.T) {
+
+		//given
+		path := filepath.Join(t.TempDir(), "access-rules.json")
+		operator := &FilesOperator{Log: logr.Discard(), RulesFilePath: path}
+		require.NoError(t, operator.CreateOrUpdate(context.Background(), noRules, nil))
+		past := time.Now().Add(-time.Hour).Truncate(time.Second)
+		require.NoError(t, os.Chtimes(path, past, past))
+
+		//when
+		require.NoError(t, operator.CreateOrUpdate(context.Background(), noRules, nil))
+
+		//then
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, past, info.ModTime())
+
+		//when content changes
+		rules := oathkeeperv1alpha1.RuleList{Items: []oathkeeperv1alpha1.Rule{newValidRule("r1", "default")}}
+		require.NoError(t, operator.CreateOrUpdate(context.Background(), rules, nil))
+
+		//then
+		expected, err := rules.ToOathkeeperRules()
+		require.NoError(t, err)
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, string(expected), string(content))
+	})
+
+	t.Run("should write YAML in the yaml format", func(t *testing.T) {
+
+		//given
+		path := filepath.Join(t.TempDir(), "access-rules.yaml")
+		operator := &FilesOperator{Log: logr.Discard(), RulesFilePath: path, Format: oathkeeperv1alpha1.FormatYAML}
+		rule := newValidRule("r1", "default")
+		rule.Spec.Upstream = &oathkeeperv1alpha1.Upstream{URL: "http://my-backend-service"}
+		rule.Spec.Mutators = []*oathkeeperv1alpha1.Mutator{{Handler: &oathkeeperv1alpha1.Handler{
+			Name:   "header",
+			Config: &runtime.RawExtension{Raw: []byte(`{"headers": {"X-User": "{{ print .Subject }}"}}`)},
+		}}}
+		rules := oathkeeperv1alpha1.RuleList{Items: []oathkeeperv1alpha1.Rule{rule}}
+
+		//when
+		require.NoError(t, operator.CreateOrUpdate(context.Background(), rules, nil))
+
+		//then
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, `- authenticators:
+  - handler: unauthorized
+  authorizer:
+    handler: deny
+  id: r1.default
+  match:
+    methods:
+    - GET
+    url: http://my-app/r1
+  mutators:
+  - config:
+      headers:
+        X-User: '{{ print .Subject }}'
+    handler: header
+  upstream:
+    preserve_host: false
+    url: http://my-backend-service
+`, string(content))
+	})
+}
@@ -0,0 +1,80 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// AllowRulesLabel opts an existing ConfigMap that was not created by maester in as a target of spec.configMapName
+	AllowRulesLabel = "oathkeeper.ory.sh/allow-rules"
+	// ManagedKeysAnnotation lists the keys of a ConfigMap written by maester, all other keys are left alone
+	ManagedKeysAnnotation = "oathkeeper.ory.sh/managed-keys"
+
+	// ReasonConfigMapNotOwned is the reason of Rule statuses when their target is a ConfigMap maester may not write to
+	ReasonConfigMapNotOwned = "ConfigMapNotOwned"
+)
+
+// NotOwnedError is returned when rules would be written into an existing ConfigMap that neither maester created nor
+// carries AllowRulesLabel. Nothing is written.
+type NotOwnedError struct {
+	ConfigMap types.NamespacedName
+}
+
+func (e *NotOwnedError) Error() string {
+	return fmt.Sprintf("ConfigMap %s was not created by oathkeeper-maester and is not labelled %s=true, refusing to write rules into it",
+		e.ConfigMap, AllowRulesLabel)
+}
+
+// writable tells whether maester may write rules into an existing ConfigMap
+func writable(configMap *apiv1.ConfigMap) bool {
+	return configMap.Labels[ManagedByLabel] == ManagedByValue || configMap.Labels[AllowRulesLabel] == "true"
+}
+
+// adoptable tells whether an existing ConfigMap looks like one created by a version of maester that didn't label its
+// ConfigMaps yet: it holds nothing but an array of rules under the rules key. It is labelled when it is written.
+func (cmo *ConfigMapOperator) adoptable(configMap *apiv1.ConfigMap) bool {
+	content, ok := configMap.Data[cmo.RulesFileName]
+	if !ok || len(configMap.Data) != 1 || len(configMap.BinaryData) != 0 {
+		return false
+	}
+	raw, err := cmo.Format.Decode([]byte(content))
+	if err != nil {
+		return false
+	}
+	var rules []json.RawMessage
+	return json.Unmarshal(raw, &rules) == nil
+}
+
+// managedKeys returns the sorted keys of data, as stored in ManagedKeysAnnotation
+func managedKeys(data map[string]string) string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
+// managedData returns the entries of a ConfigMap that maester wrote before or is about to write
+func managedData(configMap *apiv1.ConfigMap, desired map[string]string) map[string]string {
+	managed := map[string]string{}
+	keys := strings.Split(configMap.Annotations[ManagedKeysAnnotation], ",")
+	for k := range desired {
+		keys = append(keys, k)
+	}
+	for _, k := range keys {
+		if v, ok := configMap.Data[k]; ok {
+			managed[k] = v
+		}
+	}
+	return managed
+}
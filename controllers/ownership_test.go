@@ -0,0 +1,159 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestConfigMapOperatorOwnership(t *testing.T) {
+
+	ctx := context.Background()
+	target := types.NamespacedName{Name: "app-config", Namespace: "default"}
+	rule := newValidRule("r1", "default")
+	rule.Spec.ConfigMapName = &target.Name
+	rules := oathkeeperv1alpha1.RuleList{Items: []oathkeeperv1alpha1.Rule{rule}}
+
+	newForeignConfigMap := func(labels map[string]string) *apiv1.ConfigMap {
+		return &apiv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: target.Name, Namespace: target.Namespace, Labels: labels},
+			Data:       map[string]string{"app.properties": "debug=false"},
+		}
+	}
+
+	t.Run("should refuse to write into a ConfigMap it doesn't own", func(t *testing.T) {
+
+		//given
+		reconciler, _ := newConfigMapReconciler(t, newForeignConfigMap(nil))
+		operator := reconciler.ConfigMapOperator
+
+		//when
+		err := operator.CreateOrUpdate(ctx, rules, &rule)
+
+		//then
+		var notOwned *NotOwnedError
+		require.True(t, errors.As(err, &notOwned))
+		assert.Equal(t, target, notOwned.ConfigMap)
+
+		var actual apiv1.ConfigMap
+		require.NoError(t, operator.Get(ctx, target, &actual))
+		assert.Equal(t, map[string]string{"app.properties": "debug=false"}, actual.Data)
+	})
+
+	t.Run("should write into an opted-in ConfigMap and keep unrelated keys", func(t *testing.T) {
+
+		//given
+		reconciler, _ := newConfigMapReconciler(t, newForeignConfigMap(map[string]string{AllowRulesLabel: "true"}))
+		operator := reconciler.ConfigMapOperator
+
+		//when
+		require.NoError(t, operator.CreateOrUpdate(ctx, rules, &rule))
+
+		//then
+		var actual apiv1.ConfigMap
+		require.NoError(t, operator.Get(ctx, target, &actual))
+		assert.Equal(t, "debug=false", actual.Data["app.properties"])
+		assert.Contains(t, actual.Data, "access-rules.json")
+		assert.Equal(t, "access-rules.json", actual.Annotations[ManagedKeysAnnotation])
+	})
+
+	t.Run("should remove keys it doesn't write anymore", func(t *testing.T) {
+
+		//given
+		reconciler, _ := newConfigMapReconciler(t, newForeignConfigMap(map[string]string{AllowRulesLabel: "true"}))
+		operator := reconciler.ConfigMapOperator
+		operator.Sharding = Sharding{Strategy: ShardingNamespace}
+		require.NoError(t, operator.CreateOrUpdate(ctx, rules, &rule))
+		operator.Sharding = Sharding{}
+
+		//when
+		require.NoError(t, operator.CreateOrUpdate(ctx, rules, &rule))
+
+		//then
+		var actual apiv1.ConfigMap
+		require.NoError(t, operator.Get(ctx, target, &actual))
+		assert.ElementsMatch(t, []string{"app.properties", "access-rules.json"}, keysOf(actual.Data))
+	})
+
+	t.Run("should adopt an unlabelled ConfigMap holding nothing but rules", func(t *testing.T) {
+
+		//given
+		configMap := newManagedConfigMap(target, "[]")
+		configMap.Labels = nil
+		reconciler, _ := newConfigMapReconciler(t, configMap)
+		operator := reconciler.ConfigMapOperator
+		require.Error(t, operator.CreateOrUpdate(ctx, rules, &rule))
+		operator.AdoptConfigMaps = true
+
+		//when
+		require.NoError(t, operator.CreateOrUpdate(ctx, rules, &rule))
+
+		//then
+		var actual apiv1.ConfigMap
+		require.NoError(t, operator.Get(ctx, target, &actual))
+		assert.Equal(t, ManagedByValue, actual.Labels[ManagedByLabel])
+		assert.Contains(t, actual.Data["access-rules.json"], "r1.default")
+	})
+
+	t.Run("should not adopt an unlabelled ConfigMap with other keys", func(t *testing.T) {
+
+		//given
+		configMap := newForeignConfigMap(nil)
+		configMap.Data["access-rules.json"] = "[]"
+		reconciler, _ := newConfigMapReconciler(t, configMap)
+		operator := reconciler.ConfigMapOperator
+		operator.AdoptConfigMaps = true
+
+		//when
+		err := operator.CreateOrUpdate(ctx, rules, &rule)
+
+		//then
+		var notOwned *NotOwnedError
+		assert.True(t, errors.As(err, &notOwned))
+	})
+
+	t.Run("should check ownership past the cache of managed ConfigMaps", func(t *testing.T) {
+
+		//given
+		reconciler, _ := newConfigMapReconciler(t)
+		operator := reconciler.ConfigMapOperator
+		apiServer, _ := newConfigMapReconciler(t, newForeignConfigMap(nil))
+		operator.APIReader = apiServer.Client
+
+		//when
+		err := operator.CreateOrUpdate(ctx, rules, &rule)
+
+		//then
+		var notOwned *NotOwnedError
+		assert.True(t, errors.As(err, &notOwned))
+	})
+
+	t.Run("should write into the default ConfigMap without a label", func(t *testing.T) {
+
+		//given
+		configMap := newManagedConfigMap(defaultConfigMap, "[]")
+		configMap.Labels = nil
+		reconciler, _ := newConfigMapReconciler(t, configMap)
+
+		//then
+		assert.NoError(t, reconciler.CreateOrUpdate(ctx, rules, nil))
+	})
+}
+
+func keysOf(data map[string]string) []string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,80 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	authorizationv1client "k8s.io/client-go/kubernetes/typed/authorization/v1"
+)
+
+// Permission is an API access maester depends on
+type Permission struct {
+	Group    string
+	Resource string
+	Verb     string
+}
+
+func (p Permission) String() string {
+	if p.Group == "" {
+		return p.Verb + " " + p.Resource
+	}
+	return p.Verb + " " + p.Resource + "." + p.Group
+}
+
+// RequiredPermissions returns the permissions maester needs in its namespace when running namespaced
+func RequiredPermissions(sidecar, leaderElection bool) []Permission {
+	var perms []Permission
+	add := func(group, resource string, verbs ...string) {
+		for _, verb := range verbs {
+			perms = append(perms, Permission{Group: group, Resource: resource, Verb: verb})
+		}
+	}
+
+	add("oathkeeper.ory.sh", "rules", "get", "list", "watch", "update")
+	add("oathkeeper.ory.sh", "rulesets", "get", "list", "watch", "update")
+	add("oathkeeper.ory.sh", "ruledefaults", "get", "list", "watch")
+	add("oathkeeper.ory.sh", "handlerpresets", "get", "list", "watch")
+	add("events.k8s.io", "events", "create", "patch")
+	if !sidecar {
+		add("", "configmaps", "get", "list", "watch", "create", "update", "delete")
+	}
+	if leaderElection {
+		add("coordination.k8s.io", "leases", "get", "create", "update")
+	}
+	return perms
+}
+
+// CheckPermissions asks the API server with SelfSubjectAccessReviews whether all permissions are granted in the namespace
+// and lists every missing one in the returned error
+func CheckPermissions(ctx context.Context, reviews authorizationv1client.SelfSubjectAccessReviewInterface, namespace string, perms []Permission) error {
+	var missing []string
+	for _, p := range perms {
+		review, err := reviews.Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace: namespace,
+					Verb:      p.Verb,
+					Group:     p.Group,
+					Resource:  p.Resource,
+				},
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("unable to review permission to %s: %w", p, err)
+		}
+		if !review.Status.Allowed {
+			missing = append(missing, p.String())
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("missing permissions in namespace %s: %s", namespace, strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/yaml"
+)
+
+func TestCheckPermissions(t *testing.T) {
+
+	perms := RequiredPermissions(false, false)
+
+	t.Run("should pass when every permission is granted", func(t *testing.T) {
+
+		//given
+		clientset := newReviewingClientset(func(*authorizationv1.ResourceAttributes) bool { return true })
+
+		//when
+		err := CheckPermissions(context.Background(), clientset.AuthorizationV1().SelfSubjectAccessReviews(), "team-a", perms)
+
+		//then
+		assert.NoError(t, err)
+	})
+
+	t.Run("should list every missing permission", func(t *testing.T) {
+
+		//given
+		var namespaces []string
+		clientset := newReviewingClientset(func(attrs *authorizationv1.ResourceAttributes) bool {
+			namespaces = append(namespaces, attrs.Namespace)
+			return attrs.Resource != "configmaps" || attrs.Verb != "delete"
+		})
+
+		//when
+		err := CheckPermissions(context.Background(), clientset.AuthorizationV1().SelfSubjectAccessReviews(), "team-a", perms)
+
+		//then
+		assert.EqualError(t, err, "missing permissions in namespace team-a: delete configmaps")
+		assert.Len(t, namespaces, len(perms))
+		assert.Subset(t, []string{"team-a"}, namespaces)
+	})
+
+	t.Run("should not need ConfigMaps in sidecar mode", func(t *testing.T) {
+		for _, p := range RequiredPermissions(true, true) {
+			assert.NotEqual(t, "configmaps", p.Resource)
+		}
+	})
+}
+
+func TestNamespacedRole(t *testing.T) {
+
+	//given
+	raw, err := os.ReadFile("../config/rbac/namespaced/role.yaml")
+	require.NoError(t, err)
+	var role rbacv1.Role
+	require.NoError(t, yaml.Unmarshal(raw, &role))
+
+	//then
+	for _, p := range RequiredPermissions(false, false) {
+		granted := false
+		for _, rule := range role.Rules {
+			granted = granted || (slices.Contains(rule.APIGroups, p.Group) && slices.Contains(rule.Resources, p.Resource) && slices.Contains(rule.Verbs, p.Verb))
+		}
+		assert.True(t, granted, "namespaced Role doesn't grant %s", p)
+	}
+}
+
+func newReviewingClientset(allowed func(*authorizationv1.ResourceAttributes) bool) *fake.Clientset {
+	clientset := fake.NewClientset()
+	clientset.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		review.Status.Allowed = allowed(review.Spec.ResourceAttributes)
+		return true, review, nil
+	})
+	return clientset
+}
@@ -0,0 +1,124 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"errors"
+
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PresetError is returned for Rules referencing a preset that can't be resolved. It is reported as a validation error.
+type PresetError struct {
+	Ref    oathkeeperv1alpha1.PresetRef
+	Reason string
+}
+
+func (e *PresetError) Error() string {
+	return "handler preset " + e.Ref.String() + " " + e.Reason
+}
+
+// PresetResolver replaces the presetRefs of Rules with the handlers of their presets
+type PresetResolver struct {
+	client.Reader
+	// Cluster enables ClusterHandlerPresets, namespaced instances can't read them
+	Cluster bool
+}
+
+// +kubebuilder:rbac:groups=oathkeeper.ory.sh,resources=handlerpresets;clusterhandlerpresets,verbs=get;list;watch
+
+// Resolve returns copies of the Rules with their presets resolved. Rules referencing a preset that can't be resolved
+// are left out, they are reported when validated. A nil PresetResolver returns the Rules as they are.
+func (p *PresetResolver) Resolve(ctx context.Context, rules oathkeeperv1alpha1.RuleList) (oathkeeperv1alpha1.RuleList, error) {
+	if p == nil {
+		return rules, nil
+	}
+
+	rlCopy := rules
+	rlCopy.Items = make([]oathkeeperv1alpha1.Rule, 0, len(rules.Items))
+	for i := range rules.Items {
+		resolved, err := p.ResolveRule(ctx, &rules.Items[i])
+		if err != nil {
+			var presetErr *PresetError
+			if errors.As(err, &presetErr) {
+				continue
+			}
+			return rules, err
+		}
+		rlCopy.Items = append(rlCopy.Items, *resolved)
+	}
+	return rlCopy, nil
+}
+
+// ResolveRule returns a copy of the Rule with its presets resolved, or a *PresetError if one of them can't be.
+// A nil PresetResolver returns the Rule as it is.
+func (p *PresetResolver) ResolveRule(ctx context.Context, rule *oathkeeperv1alpha1.Rule) (*oathkeeperv1alpha1.Rule, error) {
+	if p == nil {
+		return rule, nil
+	}
+
+	resolved := rule.DeepCopy()
+	for _, h := range resolved.Spec.Handlers() {
+		if h.PresetRef == nil {
+			continue
+		}
+		preset, err := p.preset(ctx, rule.Namespace, *h.PresetRef)
+		if err != nil {
+			return nil, err
+		}
+		withPreset, err := h.WithPreset(*preset)
+		if err != nil {
+			return nil, &PresetError{Ref: *h.PresetRef, Reason: err.Error()}
+		}
+		*h = *withPreset
+	}
+	return resolved, nil
+}
+
+func (p *PresetResolver) preset(ctx context.Context, namespace string, ref oathkeeperv1alpha1.PresetRef) (*oathkeeperv1alpha1.HandlerPresetSpec, error) {
+	var err error
+	var spec oathkeeperv1alpha1.HandlerPresetSpec
+	if ref.IsCluster() {
+		if !p.Cluster {
+			return nil, &PresetError{Ref: ref, Reason: "can't be used in namespaced mode"}
+		}
+		var preset oathkeeperv1alpha1.ClusterHandlerPreset
+		err = p.Get(ctx, types.NamespacedName{Name: ref.Name}, &preset)
+		spec = preset.Spec
+	} else {
+		if namespace == "" {
+			return nil, &PresetError{Ref: ref, Reason: "can't be used by ClusterRules"}
+		}
+		var preset oathkeeperv1alpha1.HandlerPreset
+		err = p.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, &preset)
+		spec = preset.Spec
+	}
+	if apierrs.IsNotFound(err) {
+		return nil, &PresetError{Ref: ref, Reason: "not found"}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// references tells whether the Rule uses the preset, which lives in the given namespace or in the cluster if it is empty
+func references(rule oathkeeperv1alpha1.Rule, preset types.NamespacedName) bool {
+	if preset.Namespace != "" && preset.Namespace != rule.Namespace {
+		return false
+	}
+	for _, h := range rule.Spec.Handlers() {
+		if h.PresetRef == nil || h.PresetRef.Name != preset.Name {
+			continue
+		}
+		if h.PresetRef.IsCluster() == (preset.Namespace == "") {
+			return true
+		}
+	}
+	return false
+}
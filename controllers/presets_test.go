@@ -0,0 +1,136 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPresetResolver(t *testing.T) {
+
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, oathkeeperv1alpha1.AddToScheme(scheme))
+
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&oathkeeperv1alpha1.HandlerPreset{
+			ObjectMeta: metav1.ObjectMeta{Name: "jwt", Namespace: "team-a"},
+			Spec: oathkeeperv1alpha1.HandlerPresetSpec{
+				Name:   "jwt",
+				Config: &runtime.RawExtension{Raw: []byte(`{"jwks_urls": ["https://idp/jwks"], "scope_strategy": "none", "target_audience": ["api"]}`)},
+			},
+		},
+		&oathkeeperv1alpha1.ClusterHandlerPreset{
+			ObjectMeta: metav1.ObjectMeta{Name: "hydrator"},
+			Spec:       oathkeeperv1alpha1.HandlerPresetSpec{Name: "hydrator"},
+		},
+	).Build()
+	resolver := &PresetResolver{Reader: reader, Cluster: true}
+
+	withPresets := func(name, namespace string, refs ...oathkeeperv1alpha1.PresetRef) oathkeeperv1alpha1.Rule {
+		rule := newValidRule(name, namespace)
+		for _, ref := range refs {
+			rule.Spec.Authenticators = append(rule.Spec.Authenticators, &oathkeeperv1alpha1.Authenticator{Handler: &oathkeeperv1alpha1.Handler{PresetRef: &ref}})
+		}
+		return rule
+	}
+
+	t.Run("should take handler and config from the preset and lay the overlay over it", func(t *testing.T) {
+
+		//given
+		rule := withPresets("r1", "team-a", oathkeeperv1alpha1.PresetRef{Name: "jwt"})
+		rule.Spec.Authenticators[0].Config = &runtime.RawExtension{Raw: []byte(`{"target_audience": ["admin"], "scope_strategy": null}`)}
+
+		//when
+		resolved, err := resolver.ResolveRule(ctx, &rule)
+
+		//then
+		require.NoError(t, err)
+		authenticator := resolved.Spec.Authenticators[0]
+		assert.Equal(t, "jwt", authenticator.Name)
+		assert.Nil(t, authenticator.PresetRef)
+		assert.JSONEq(t, `{"jwks_urls": ["https://idp/jwks"], "target_audience": ["admin"]}`, string(authenticator.Config.Raw))
+		assert.NotNil(t, rule.Spec.Authenticators[0].PresetRef, "the Rule must not be changed")
+	})
+
+	t.Run("should resolve ClusterHandlerPresets", func(t *testing.T) {
+
+		//given
+		rule := withPresets("r1", "team-b", oathkeeperv1alpha1.PresetRef{Name: "hydrator", Kind: oathkeeperv1alpha1.ClusterHandlerPresetKind})
+
+		//when
+		resolved, err := resolver.ResolveRule(ctx, &rule)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, "hydrator", resolved.Spec.Authenticators[0].Name)
+	})
+
+	t.Run("should report presets that can't be resolved", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			resolver *PresetResolver
+			rule     oathkeeperv1alpha1.Rule
+			expected string
+		}{
+			"missing": {
+				resolver: resolver,
+				rule:     withPresets("r1", "team-b", oathkeeperv1alpha1.PresetRef{Name: "jwt"}),
+				expected: "handler preset HandlerPreset/jwt not found",
+			},
+			"cluster in namespaced mode": {
+				resolver: &PresetResolver{Reader: reader},
+				rule:     withPresets("r1", "team-a", oathkeeperv1alpha1.PresetRef{Name: "hydrator", Kind: oathkeeperv1alpha1.ClusterHandlerPresetKind}),
+				expected: "handler preset ClusterHandlerPreset/hydrator can't be used in namespaced mode",
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+
+				//when
+				_, err := tc.resolver.ResolveRule(ctx, &tc.rule)
+
+				//then
+				var presetErr *PresetError
+				require.True(t, errors.As(err, &presetErr))
+				assert.EqualError(t, err, tc.expected)
+			})
+		}
+	})
+
+	t.Run("should leave out Rules with presets that can't be resolved", func(t *testing.T) {
+
+		//given
+		rules := oathkeeperv1alpha1.RuleList{Items: []oathkeeperv1alpha1.Rule{
+			withPresets("r1", "team-a", oathkeeperv1alpha1.PresetRef{Name: "jwt"}),
+			withPresets("r2", "team-b", oathkeeperv1alpha1.PresetRef{Name: "jwt"}),
+		}}
+
+		//when
+		resolved, err := resolver.Resolve(ctx, rules)
+
+		//then
+		require.NoError(t, err)
+		assert.Equal(t, []string{"r1"}, names(resolved))
+	})
+
+	t.Run("should tell which Rules reference a preset", func(t *testing.T) {
+
+		//given
+		rule := withPresets("r1", "team-a", oathkeeperv1alpha1.PresetRef{Name: "jwt"})
+
+		//then
+		assert.True(t, references(rule, types.NamespacedName{Name: "jwt", Namespace: "team-a"}))
+		assert.False(t, references(rule, types.NamespacedName{Name: "jwt", Namespace: "team-b"}))
+		assert.False(t, references(rule, types.NamespacedName{Name: "jwt"}))
+	})
+}
@@ -5,8 +5,10 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -15,9 +17,14 @@ import (
 	"github.com/ory/oathkeeper-maester/internal/validation"
 
 	"github.com/avast/retry-go"
+	apiv1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 const (
@@ -32,12 +39,24 @@ type RuleReconciler struct {
 	client.Client
 	Log              logr.Logger
 	ValidationConfig validation.Config
+	Claim            Claim
+	// Baseline reserves the IDs of its rules, Rules rendering to one of them are not valid
+	Baseline *oathkeeperv1alpha1.Baseline
+	// Defaulter fills in what Rules omit before they are validated and rendered
+	Defaulter *RuleDefaulter
+	// Presets resolves the presetRefs of Rules after defaulting
+	Presets *PresetResolver
+	// ClusterRules renders ClusterRules into the default target and reserves their IDs, namespaced instances can't read them
+	ClusterRules bool
+	// IDScheme derives the IDs of Rules without spec.id, nil keeps <name>.<namespace>
+	IDScheme *RuleIDScheme
 	OperatorMode
 }
 
 // +kubebuilder:rbac:groups=oathkeeper.ory.sh,resources=rules,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=oathkeeper.ory.sh,resources=rules/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 
 // Reconcile main reconcile loop
 func (r *RuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -56,11 +75,38 @@ func (r *RuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		return ctrl.Result{}, err
 	}
 
+	claimed := r.Claim.Matches(&rule)
+	if claimed {
+		namespaceClaimed, err := r.Claim.namespaceClaimed(ctx, r.Client, rule.Namespace)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		claimed = namespaceClaimed
+	}
+	if !claimed {
+		// the Rule is meant for another instance, only render it out of the target it may have been in
+		skipValidation = true
+		if err := r.release(ctx, &rule); err != nil {
+			r.Log.Error(err, "unable to release Rule")
+			return ctrl.Result{Requeue: true}, nil
+		}
+	}
+
 	if !skipValidation {
-		if err := rule.ValidateWith(r.ValidationConfig); err != nil {
-			rule.Status.Validation = &oathkeeperv1alpha1.Validation{}
+		invalid, err := r.check(ctx, &rule)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		warnings, err := r.deprecationWarnings(ctx, &rule)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := invalid; err != nil {
+			rule.Status.Validation = &oathkeeperv1alpha1.Validation{Warnings: warnings}
 			rule.Status.Validation.Valid = boolPtr(false)
 			rule.Status.Validation.Error = stringPtr(err.Error())
+			rule.Status.Matches = r.IDScheme.ApplyTo(&rule).MatchStatuses()
+			rule.Status.ClaimedBy = stringPtr(r.Claim.String())
 			r.Log.Info(fmt.Sprintf("validation error in Rule %s/%s: \"%s\"", rule.Namespace, rule.Name, err.Error()))
 			if err := r.Update(ctx, &rule); err != nil {
 				r.Log.Error(err, "unable to update Rule status")
@@ -70,8 +116,10 @@ func (r *RuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 			// continue, as validation can't be fixed by requeuing request and we still have to update the configmap
 		} else {
 			// rule valid - set the status
-			rule.Status.Validation = &oathkeeperv1alpha1.Validation{}
+			rule.Status.Validation = &oathkeeperv1alpha1.Validation{Warnings: warnings}
 			rule.Status.Validation.Valid = boolPtr(true)
+			rule.Status.Matches = r.IDScheme.ApplyTo(&rule).MatchStatuses()
+			rule.Status.ClaimedBy = stringPtr(r.Claim.String())
 			if err := r.Update(ctx, &rule); err != nil {
 				r.Log.Error(err, "unable to update Rule status")
 				//Invoke requeue directly without logging error with whole stacktrace
@@ -80,20 +128,19 @@ func (r *RuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		}
 	}
 
-	var rulesList oathkeeperv1alpha1.RuleList
-
+	var listOpts []client.ListOption
 	if rule.Spec.ConfigMapName != nil {
-		if err := r.List(ctx, &rulesList, client.InNamespace(req.NamespacedName.Namespace)); err != nil {
-			return ctrl.Result{}, err
-		}
-	} else {
-		if err := r.List(ctx, &rulesList); err != nil {
-			return ctrl.Result{}, err
-		}
+		listOpts = append(listOpts, client.InNamespace(req.NamespacedName.Namespace))
+	}
+	rulesList, err := r.claimedRules(ctx, listOpts...)
+	if err != nil {
+		return ctrl.Result{}, err
 	}
 
 	// examine DeletionTimestamp to determine if object is under deletion
-	if rule.ObjectMeta.DeletionTimestamp.IsZero() {
+	if !claimed {
+		// finalizing is left to the instance claiming the Rule
+	} else if rule.ObjectMeta.DeletionTimestamp.IsZero() {
 		// The object is not being deleted, so if it does not have our finalizer,
 		// then lets add the finalizer and update the object. This is equivalent
 		// registering our finalizer.
@@ -117,34 +164,301 @@ func (r *RuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		}
 	}
 
-	var err error
-	var oathkeeperRulesJSON []byte
-
 	if rule.Spec.ConfigMapName != nil {
 		r.Log.Info(fmt.Sprintf("Found ConfigMap definition in Rule %s/%s: Writing data to \"%s\"", rule.Namespace, rule.Name, *rule.Spec.ConfigMapName))
-		oathkeeperRulesJSON, err = rulesList.FilterNotValid().FilterConfigMapName(rule.Spec.ConfigMapName).ToOathkeeperRules()
-		if err != nil {
-			return ctrl.Result{}, err
+	}
+	targetRules, err := r.targetRules(ctx, rulesList, &rule)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	r.write(ctx, targetRules, &rule)
+	return ctrl.Result{}, nil
+}
+
+// claimedRules lists the claimed Rules, including the routes of RuleSets, with their defaults and presets resolved
+func (r *RuleReconciler) claimedRules(ctx context.Context, opts ...client.ListOption) (oathkeeperv1alpha1.RuleList, error) {
+	rulesList, err := listRules(ctx, r.Client, opts...)
+	if err != nil {
+		return rulesList, err
+	}
+	rulesList, err = r.Claim.FilterNamespaces(ctx, r.Client, r.IDScheme.Apply(r.Claim.Filter(rulesList)))
+	if err != nil {
+		return rulesList, err
+	}
+	rulesList, err = r.Defaulter.Apply(ctx, rulesList)
+	if err != nil {
+		return rulesList, err
+	}
+	return r.Presets.Resolve(ctx, rulesList)
+}
+
+// targetRules returns the valid Rules of the target of the triggering Rule
+func (r *RuleReconciler) targetRules(ctx context.Context, rulesList oathkeeperv1alpha1.RuleList, triggeredBy *oathkeeperv1alpha1.Rule) (oathkeeperv1alpha1.RuleList, error) {
+	if triggeredBy.Spec.ConfigMapName != nil {
+		return renderedRules(rulesList.FilterConfigMapName(triggeredBy.Spec.ConfigMapName), oathkeeperv1alpha1.RuleList{}), nil
+	}
+	clusterRules, err := listClusterRules(ctx, r.Client, r.ClusterRules, r.Claim, r.IDScheme, r.Defaulter, r.Presets)
+	if err != nil {
+		return rulesList, err
+	}
+	return renderedRules(rulesList, clusterRules), nil
+}
+
+// renderedRules returns the Rules and ClusterRules that are rendered into a target: the valid ones that are not being
+// deleted, without duplicate IDs. The Rule reconcilers and the drift reconciler all render through it, so they never
+// overwrite each other while a finalizer is pending.
+func renderedRules(rules, clusterRules oathkeeperv1alpha1.RuleList) oathkeeperv1alpha1.RuleList {
+	return withoutDuplicateIDs(withClusterRules(rules.FilterDeleted().FilterNotValid(), clusterRules.FilterDeleted().FilterNotValid()))
+}
+
+// write renders the Rules into the target of the triggering Rule and reports errors in the status of the affected Rules
+func (r *RuleReconciler) write(ctx context.Context, targetRules oathkeeperv1alpha1.RuleList, triggeredBy *oathkeeperv1alpha1.Rule) {
+	if err := r.OperatorMode.CreateOrUpdate(ctx, targetRules, triggeredBy); err != nil {
+		var tooLarge *TooLargeError
+		var notOwned *NotOwnedError
+		var notMergeable *NotMergeableError
+		var conflict *ConflictError
+		switch {
+		case errors.As(err, &tooLarge):
+			r.markNotSynced(ctx, tooLarge.Offenders, tooLargeStatus(tooLarge))
+		case errors.As(err, &notOwned):
+			r.markNotSynced(ctx, refsOf(targetRules, triggeredBy), notSyncedStatus(ReasonConfigMapNotOwned, err))
+		case errors.As(err, &notMergeable):
+			r.markNotSynced(ctx, refsOf(targetRules, triggeredBy), notSyncedStatus(ReasonConfigMapNotMergeable, err))
+		case errors.As(err, &conflict):
+			// the target was written without the conflicting Rules
+			r.clearNotSynced(ctx, without(targetRules, conflict.Rules))
+			r.markNotSynced(ctx, conflict.Rules, notSyncedStatus(ReasonRuleIDConflict, err))
+		default:
+			r.Log.Error(err, "unable to process rules Configmap")
+			os.Exit(1)
 		}
-	} else {
-		oathkeeperRulesJSON, err = rulesList.FilterNotValid().ToOathkeeperRules()
-		if err != nil {
-			return ctrl.Result{}, err
+		r.Log.Info(fmt.Sprintf("not all rules were written: %s", err))
+		return
+	}
+
+	r.clearNotSynced(ctx, targetRules)
+}
+
+// release clears the claim of this instance from a Rule it no longer claims. A Rule being deleted that no other
+// instance claims is finalized right away, so it doesn't get stuck.
+func (r *RuleReconciler) release(ctx context.Context, rule *oathkeeperv1alpha1.Rule) error {
+	claimedBy := rule.Status.ClaimedBy
+	ours := claimedBy != nil && *claimedBy == r.Claim.String()
+	if !ours && claimedBy != nil {
+		return nil
+	}
+
+	deleting := !rule.ObjectMeta.DeletionTimestamp.IsZero() && containsString(rule.ObjectMeta.Finalizers, FinalizerName)
+	if !ours && !deleting {
+		return nil
+	}
+
+	rule.Status.ClaimedBy = nil
+	if deleting {
+		rule.ObjectMeta.Finalizers = removeString(rule.ObjectMeta.Finalizers, FinalizerName)
+	}
+	return r.Update(ctx, rule)
+}
+
+// markNotSynced sets the sync status of Rules whose target could not be written
+func (r *RuleReconciler) markNotSynced(ctx context.Context, refs []types.NamespacedName, status *oathkeeperv1alpha1.Sync) {
+	for _, ref := range refs {
+		if err := r.updateStatus(ctx, ref, func(s *oathkeeperv1alpha1.RuleStatus) { s.Sync = status }); err != nil {
+			r.Log.Error(err, fmt.Sprintf("unable to update status of Rule %s", ref))
 		}
 	}
+}
 
-	if err := r.OperatorMode.CreateOrUpdate(ctx, oathkeeperRulesJSON, &rule); err != nil {
-		r.Log.Error(err, "unable to process rules Configmap")
-		os.Exit(1)
+// clearNotSynced resets the sync status of Rules marked by markNotSynced once their target is written again.
+// The rendered Rules carry their defaults and resolved presets, so the status is written to a fresh copy.
+func (r *RuleReconciler) clearNotSynced(ctx context.Context, rules oathkeeperv1alpha1.RuleList) {
+	for _, rule := range rules.Items {
+		if rule.Status.Sync == nil || rule.Status.Sync.Synced == nil || *rule.Status.Sync.Synced {
+			continue
+		}
+		ref := types.NamespacedName{Name: rule.Name, Namespace: rule.Namespace}
+		if err := r.updateStatus(ctx, ref, func(s *oathkeeperv1alpha1.RuleStatus) { s.Sync = nil }); err != nil {
+			r.Log.Error(err, fmt.Sprintf("unable to update status of Rule %s", ref))
+		}
 	}
-	return ctrl.Result{}, nil
+}
+
+// updateStatus changes the status of the Rule, of the ClusterRule if the reference has no namespace, or of the
+// route if it references the Rule of a route
+func (r *RuleReconciler) updateStatus(ctx context.Context, ref types.NamespacedName, change func(*oathkeeperv1alpha1.RuleStatus)) error {
+	if name, route, ok := oathkeeperv1alpha1.ParseRouteRuleName(ref.Name); ok {
+		var ruleSet oathkeeperv1alpha1.RuleSet
+		if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: ref.Namespace}, &ruleSet); err != nil {
+			return err
+		}
+		status := ruleSet.Status.Route(route)
+		if status == nil {
+			ruleSet.Status.Routes = append(ruleSet.Status.Routes, oathkeeperv1alpha1.RouteStatus{Name: route})
+			status = &ruleSet.Status.Routes[len(ruleSet.Status.Routes)-1]
+		}
+		ruleStatus := oathkeeperv1alpha1.RuleStatus{Validation: status.Validation, Sync: status.Sync, ClaimedBy: ruleSet.Status.ClaimedBy}
+		change(&ruleStatus)
+		status.Validation, status.Sync = ruleStatus.Validation, ruleStatus.Sync
+		return r.Update(ctx, &ruleSet)
+	}
+	if ref.Namespace == "" {
+		var clusterRule oathkeeperv1alpha1.ClusterRule
+		if err := r.Get(ctx, ref, &clusterRule); err != nil {
+			return err
+		}
+		change(&clusterRule.Status)
+		return r.Update(ctx, &clusterRule)
+	}
+
+	var rule oathkeeperv1alpha1.Rule
+	if err := r.Get(ctx, ref, &rule); err != nil {
+		return err
+	}
+	change(&rule.Status)
+	return r.Update(ctx, &rule)
+}
+
+// notSyncedStatus describes an error writing the target in the status of its Rules
+func notSyncedStatus(reason string, err error) *oathkeeperv1alpha1.Sync {
+	return &oathkeeperv1alpha1.Sync{
+		Synced: boolPtr(false),
+		Reason: stringPtr(reason),
+		Error:  stringPtr(err.Error()),
+	}
+}
+
+// without returns a list of the Rules except the referenced ones
+func without(rules oathkeeperv1alpha1.RuleList, refs []types.NamespacedName) oathkeeperv1alpha1.RuleList {
+	rlCopy := rules
+	rlCopy.Items = []oathkeeperv1alpha1.Rule{}
+	for _, rule := range rules.Items {
+		if !slices.Contains(refs, types.NamespacedName{Name: rule.Name, Namespace: rule.Namespace}) {
+			rlCopy.Items = append(rlCopy.Items, rule)
+		}
+	}
+	return rlCopy
+}
+
+// refsOf returns the references of all Rules of a target and the Rule that triggered writing it
+func refsOf(rules oathkeeperv1alpha1.RuleList, triggeredBy *oathkeeperv1alpha1.Rule) []types.NamespacedName {
+	trigger := types.NamespacedName{Name: triggeredBy.Name, Namespace: triggeredBy.Namespace}
+	refs := []types.NamespacedName{trigger}
+	for _, rule := range rules.Items {
+		if ref := (types.NamespacedName{Name: rule.Name, Namespace: rule.Namespace}); ref != trigger {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// check fills in the ID and defaults of the Rule, resolves its presets and validates the result. Problems of the
+// Rule are returned as invalid, everything else as err.
+func (r *RuleReconciler) check(ctx context.Context, rule *oathkeeperv1alpha1.Rule) (invalid, err error) {
+	rule = r.IDScheme.ApplyTo(rule)
+	resolved, invalid, err := r.resolve(ctx, rule)
+	if invalid != nil || err != nil {
+		return invalid, err
+	}
+	if err := resolved.ValidateWith(r.ValidationConfig); err != nil {
+		return err, nil
+	}
+
+	for _, id := range rule.IDs() {
+		if r.Baseline.Reserves(id) {
+			return fmt.Errorf("rule ID %s is reserved by a baseline rule", id), nil
+		}
+	}
+	return r.duplicateID(ctx, rule)
+}
+
+// resolve returns the Rule with its defaults filled in and its presets resolved. A missing preset is returned as invalid.
+func (r *RuleReconciler) resolve(ctx context.Context, rule *oathkeeperv1alpha1.Rule) (resolved *oathkeeperv1alpha1.Rule, invalid, err error) {
+	defaulted, err := r.Defaulter.ApplyTo(ctx, rule)
+	if err != nil {
+		return nil, nil, err
+	}
+	resolved, err = r.Presets.ResolveRule(ctx, defaulted)
+	var presetErr *PresetError
+	if errors.As(err, &presetErr) {
+		return nil, err, nil
+	}
+	return resolved, nil, err
+}
+
+// deprecationWarnings reports the deprecated handlers the Rule uses once its defaults and presets are resolved
+func (r *RuleReconciler) deprecationWarnings(ctx context.Context, rule *oathkeeperv1alpha1.Rule) ([]string, error) {
+	resolved, invalid, err := r.resolve(ctx, rule)
+	if invalid != nil || err != nil {
+		return nil, err
+	}
+	warnings := resolved.DeprecationWarnings(r.ValidationConfig)
+	for _, warning := range warnings {
+		r.Log.Info(fmt.Sprintf("deprecation warning in %s: %s", describe(*rule), warning))
+	}
+	return warnings, nil
+}
+
+// presetHandler enqueues the Rules using a preset, directly or through their defaults, so they are validated and rendered again
+func (r *RuleReconciler) presetHandler() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, o client.Object) []reconcile.Request {
+		preset := types.NamespacedName{Name: o.GetName(), Namespace: o.GetNamespace()}
+		rulesList, err := listRules(ctx, r.Client, client.InNamespace(preset.Namespace))
+		if err != nil {
+			return nil
+		}
+		rulesList, err = r.Defaulter.Apply(ctx, rulesList)
+		if err != nil {
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, rule := range rulesList.Items {
+			if references(rule, preset) {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: rule.Name, Namespace: rule.Namespace}})
+			}
+		}
+		return requests
+	})
 }
 
 // SetupWithManager ??
 func (r *RuleReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&oathkeeperv1alpha1.Rule{}).
-		Complete(r)
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&oathkeeperv1alpha1.Rule{}, builder.WithPredicates(r.Claim.predicate()))
+	return r.watchDependencies(b, mgr).Complete(r)
+}
+
+// watchDependencies watches what the validation and rendering of Rules depends on besides the Rules themselves
+func (r *RuleReconciler) watchDependencies(b *builder.Builder, mgr ctrl.Manager) *builder.Builder {
+	b = r.watchIDs(b)
+	if r.Claim.NamespaceSelector != nil {
+		enqueue, labelsChanged := r.Claim.namespaceHandler(mgr.GetClient())
+		b = b.Watches(&apiv1.Namespace{}, enqueue, builder.WithPredicates(labelsChanged))
+	}
+	if r.Defaulter != nil {
+		b = b.Watches(&oathkeeperv1alpha1.RuleDefaults{}, r.Defaulter.rulesHandler())
+		if r.Defaulter.Cluster {
+			b = b.Watches(&oathkeeperv1alpha1.ClusterRuleDefaults{}, r.Defaulter.rulesHandler())
+		}
+	}
+	if r.Presets != nil {
+		b = b.Watches(&oathkeeperv1alpha1.HandlerPreset{}, r.presetHandler())
+		if r.Presets.Cluster {
+			b = b.Watches(&oathkeeperv1alpha1.ClusterHandlerPreset{}, r.presetHandler())
+		}
+	}
+	return b
+}
+
+// watchIDs validates Rules again when a Rule, ClusterRule or RuleSet rendering to one of their IDs changes
+func (r *RuleReconciler) watchIDs(b *builder.Builder) *builder.Builder {
+	b = b.Watches(&oathkeeperv1alpha1.Rule{}, r.duplicateIDHandler()).
+		Watches(&oathkeeperv1alpha1.RuleSet{}, r.duplicateIDHandler())
+	if r.ClusterRules {
+		b = b.Watches(&oathkeeperv1alpha1.ClusterRule{}, r.duplicateIDHandler())
+	}
+	return b
 }
 
 func isObjectHasBeenModified(err error) bool {
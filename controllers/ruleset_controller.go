@@ -0,0 +1,165 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RuleSetReconciler reconciles RuleSets. Every route is validated and rendered like a Rule of its own.
+type RuleSetReconciler struct {
+	*RuleReconciler
+}
+
+// +kubebuilder:rbac:groups=oathkeeper.ory.sh,resources=rulesets,verbs=get;list;watch;update
+
+// Reconcile validates the routes of a RuleSet, finalizes it and renders its target
+func (r *RuleSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	// dependencies enqueue the Rules of routes, reconcile their RuleSet instead
+	name := req.Name
+	if ruleSetName, _, ok := oathkeeperv1alpha1.ParseRouteRuleName(req.Name); ok {
+		name = ruleSetName
+	}
+
+	var ruleSet oathkeeperv1alpha1.RuleSet
+	if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: req.Namespace}, &ruleSet); err != nil {
+		if apierrs.IsNotFound(err) {
+			// just return here, the finalizers have already run
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	claimed := r.Claim.MatchesRuleSet(&ruleSet)
+	if claimed {
+		namespaceClaimed, err := r.Claim.namespaceClaimed(ctx, r.Client, ruleSet.Namespace)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		claimed = namespaceClaimed
+	}
+	deleting := !ruleSet.DeletionTimestamp.IsZero()
+
+	switch {
+	case !claimed:
+		// the RuleSet is meant for another instance, only render its routes out of the target they may have been in
+		if err := r.release(ctx, &ruleSet); err != nil {
+			r.Log.Error(err, "unable to release RuleSet")
+			return ctrl.Result{Requeue: true}, nil
+		}
+	case deleting:
+		if containsString(ruleSet.Finalizers, FinalizerName) {
+			ruleSet.Finalizers = removeString(ruleSet.Finalizers, FinalizerName)
+			if err := r.Update(ctx, &ruleSet); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+	default:
+		routes := make([]oathkeeperv1alpha1.RouteStatus, 0, len(ruleSet.Spec.Routes))
+		for i, rule := range ruleSet.ToRules().Items {
+			route := ruleSet.Spec.Routes[i].Name
+			invalid, err := r.check(ctx, &rule)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			status := oathkeeperv1alpha1.RouteStatus{Name: route, Sync: rule.Status.Sync}
+			warnings, err := r.deprecationWarnings(ctx, &rule)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			status.Validation = &oathkeeperv1alpha1.Validation{Valid: boolPtr(invalid == nil), Warnings: warnings}
+			if invalid != nil {
+				status.Validation.Error = stringPtr(invalid.Error())
+				r.Log.Info(fmt.Sprintf("validation error in route %s of RuleSet %s/%s: \"%s\"", route, ruleSet.Namespace, ruleSet.Name, invalid))
+			}
+			routes = append(routes, status)
+		}
+		ruleSet.Status.Routes = routes
+		ruleSet.Status.ClaimedBy = stringPtr(r.Claim.String())
+		if !containsString(ruleSet.Finalizers, FinalizerName) {
+			ruleSet.Finalizers = append(ruleSet.Finalizers, FinalizerName)
+		}
+		if err := r.Update(ctx, &ruleSet); err != nil {
+			r.Log.Error(err, "unable to update RuleSet")
+			//Invoke requeue directly without logging error with whole stacktrace
+			return ctrl.Result{Requeue: true}, nil
+		}
+	}
+
+	// the routes stand in for the RuleSet when picking the target, they all share its UID and ConfigMapName
+	trigger := oathkeeperv1alpha1.Rule{ObjectMeta: ruleSet.ObjectMeta, Spec: oathkeeperv1alpha1.RuleSpec{ConfigMapName: ruleSet.Spec.ConfigMapName}}
+	if rules := ruleSet.ToRules(); len(rules.Items) > 0 {
+		trigger = rules.Items[0]
+	}
+
+	var listOpts []client.ListOption
+	if ruleSet.Spec.ConfigMapName != nil {
+		listOpts = append(listOpts, client.InNamespace(ruleSet.Namespace))
+	}
+	rulesList, err := r.claimedRules(ctx, listOpts...)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if deleting {
+		rulesList = rulesList.FilterOutRule(trigger)
+	}
+
+	targetRules, err := r.targetRules(ctx, rulesList, &trigger)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	r.write(ctx, targetRules, &trigger)
+	return ctrl.Result{}, nil
+}
+
+// release clears the claim of this instance from a RuleSet it no longer claims, see RuleReconciler.release
+func (r *RuleSetReconciler) release(ctx context.Context, ruleSet *oathkeeperv1alpha1.RuleSet) error {
+	claimedBy := ruleSet.Status.ClaimedBy
+	ours := claimedBy != nil && *claimedBy == r.Claim.String()
+	if !ours && claimedBy != nil {
+		return nil
+	}
+
+	deleting := !ruleSet.DeletionTimestamp.IsZero() && containsString(ruleSet.Finalizers, FinalizerName)
+	if !ours && !deleting {
+		return nil
+	}
+
+	ruleSet.Status.ClaimedBy = nil
+	if deleting {
+		ruleSet.Finalizers = removeString(ruleSet.Finalizers, FinalizerName)
+	}
+	return r.Update(ctx, ruleSet)
+}
+
+// SetupWithManager registers the reconciler for RuleSets
+func (r *RuleSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named("ruleset").
+		For(&oathkeeperv1alpha1.RuleSet{}, builder.WithPredicates(r.Claim.predicate()))
+	return r.watchDependencies(b, mgr).Complete(r)
+}
+
+// listRules lists the Rules together with the Rules the routes of RuleSets are rendered as
+func listRules(ctx context.Context, reader client.Reader, opts ...client.ListOption) (oathkeeperv1alpha1.RuleList, error) {
+	var rulesList oathkeeperv1alpha1.RuleList
+	if err := reader.List(ctx, &rulesList, opts...); err != nil {
+		return rulesList, err
+	}
+	var ruleSets oathkeeperv1alpha1.RuleSetList
+	if err := reader.List(ctx, &ruleSets, opts...); err != nil {
+		return rulesList, err
+	}
+	rulesList.Items = append(rulesList.Items, ruleSets.ToRules().Items...)
+	return rulesList, nil
+}
@@ -0,0 +1,167 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	"github.com/ory/oathkeeper-maester/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRuleSetReconcile(t *testing.T) {
+
+	//given
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiv1.AddToScheme(scheme))
+	require.NoError(t, oathkeeperv1alpha1.AddToScheme(scheme))
+
+	ruleSet := &oathkeeperv1alpha1.RuleSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "team-a", UID: "api-uid"},
+		Spec: oathkeeperv1alpha1.RuleSetSpec{
+			Authenticators: []*oathkeeperv1alpha1.Authenticator{{Handler: &oathkeeperv1alpha1.Handler{Name: "anonymous"}}},
+			Routes: []oathkeeperv1alpha1.Route{
+				{Name: "list", Match: &oathkeeperv1alpha1.Match{URL: "http://api/items", Methods: []string{"GET"}}},
+				{
+					Name:           "admin",
+					Match:          &oathkeeperv1alpha1.Match{URL: "http://api/admin", Methods: []string{"GET"}},
+					Authenticators: []*oathkeeperv1alpha1.Authenticator{{Handler: &oathkeeperv1alpha1.Handler{Name: "jwt"}}},
+				},
+			},
+		},
+	}
+	rule := newValidRule("r1", "default")
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ruleSet, &rule).Build()
+	reconciler := &RuleSetReconciler{RuleReconciler: &RuleReconciler{
+		Client: c,
+		Log:    logr.Discard(),
+		ValidationConfig: validation.Config{
+			AuthenticatorsAvailable: []string{"anonymous"},
+			AuthorizersAvailable:    []string{"deny"},
+			MutatorsAvailable:       []string{"noop"},
+		},
+		OperatorMode: &ConfigMapOperator{
+			Client:           c,
+			Log:              logr.Discard(),
+			DefaultConfigMap: defaultConfigMap,
+			RulesFileName:    "access-rules.json",
+			Recorder:         events.NewFakeRecorder(10),
+		},
+	}}
+
+	//when
+	_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "api", Namespace: "team-a"}})
+
+	//then
+	require.NoError(t, err)
+
+	var actual oathkeeperv1alpha1.RuleSet
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "api", Namespace: "team-a"}, &actual))
+	assert.Contains(t, actual.Finalizers, FinalizerName)
+	require.Len(t, actual.Status.Routes, 2)
+	assert.True(t, *actual.Status.Route("list").Validation.Valid)
+	assert.False(t, *actual.Status.Route("admin").Validation.Valid)
+	assert.Contains(t, *actual.Status.Route("admin").Validation.Error, "jwt")
+
+	expected, err := oathkeeperv1alpha1.RuleList{Items: []oathkeeperv1alpha1.Rule{rule, actual.ToRules().Items[0]}}.ToOathkeeperRules()
+	require.NoError(t, err)
+	var configMap apiv1.ConfigMap
+	require.NoError(t, c.Get(ctx, defaultConfigMap, &configMap))
+	assert.Equal(t, string(expected), configMap.Data["access-rules.json"])
+}
+
+func TestRuleSetRouteStatus(t *testing.T) {
+
+	//given
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, oathkeeperv1alpha1.AddToScheme(scheme))
+	ruleSet := &oathkeeperv1alpha1.RuleSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "team-a"},
+		Status: oathkeeperv1alpha1.RuleSetStatus{Routes: []oathkeeperv1alpha1.RouteStatus{
+			{Name: "list", Validation: &oathkeeperv1alpha1.Validation{Valid: boolPtr(true)}},
+		}},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ruleSet).Build()
+	reconciler := &RuleReconciler{Client: c, Log: logr.Discard()}
+	ref := types.NamespacedName{Name: oathkeeperv1alpha1.RouteRuleName("api", "list"), Namespace: "team-a"}
+
+	//when
+	reconciler.markNotSynced(ctx, []types.NamespacedName{ref}, notSyncedStatus(ReasonConfigMapNotOwned, assert.AnError))
+
+	//then
+	var actual oathkeeperv1alpha1.RuleSet
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "api", Namespace: "team-a"}, &actual))
+	route := actual.Status.Route("list")
+	assert.False(t, *route.Sync.Synced)
+	assert.Equal(t, ReasonConfigMapNotOwned, *route.Sync.Reason)
+	assert.True(t, *route.Validation.Valid)
+}
+
+func TestRuleSetDeprecationWarnings(t *testing.T) {
+
+	//given
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiv1.AddToScheme(scheme))
+	require.NoError(t, oathkeeperv1alpha1.AddToScheme(scheme))
+
+	ruleSet := &oathkeeperv1alpha1.RuleSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "team-a", UID: "api-uid"},
+		Spec: oathkeeperv1alpha1.RuleSetSpec{
+			Authenticators: []*oathkeeperv1alpha1.Authenticator{{Handler: &oathkeeperv1alpha1.Handler{Name: "anonymous"}}},
+			Authorizer:     &oathkeeperv1alpha1.Authorizer{Handler: &oathkeeperv1alpha1.Handler{PresetRef: &oathkeeperv1alpha1.PresetRef{Name: "keto"}}},
+			Routes: []oathkeeperv1alpha1.Route{
+				{Name: "list", Match: &oathkeeperv1alpha1.Match{URL: "http://api/items", Methods: []string{"GET"}}},
+			},
+		},
+	}
+	preset := &oathkeeperv1alpha1.HandlerPreset{
+		ObjectMeta: metav1.ObjectMeta{Name: "keto", Namespace: "team-a"},
+		Spec:       oathkeeperv1alpha1.HandlerPresetSpec{Name: "keto_engine_acp_ory"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(ruleSet, preset).Build()
+	reconciler := &RuleSetReconciler{RuleReconciler: &RuleReconciler{
+		Client:  c,
+		Log:     logr.Discard(),
+		Presets: &PresetResolver{Reader: c},
+		ValidationConfig: validation.Config{
+			AuthenticatorsAvailable: []string{"anonymous"},
+			AuthorizersAvailable:    []string{"keto_engine_acp_ory"},
+			MutatorsAvailable:       []string{"noop"},
+			Deprecated:              validation.Deprecations,
+		},
+		OperatorMode: &ConfigMapOperator{
+			Client:           c,
+			Log:              logr.Discard(),
+			DefaultConfigMap: defaultConfigMap,
+			RulesFileName:    "access-rules.json",
+			Recorder:         events.NewFakeRecorder(10),
+		},
+	}}
+
+	//when
+	_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "api", Namespace: "team-a"}})
+
+	//then
+	require.NoError(t, err)
+
+	var actual oathkeeperv1alpha1.RuleSet
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: "api", Namespace: "team-a"}, &actual))
+	validationStatus := actual.Status.Route("list").Validation
+	assert.True(t, *validationStatus.Valid)
+	assert.Equal(t, []string{"authorizer keto_engine_acp_ory is deprecated, use remote_json instead"}, validationStatus.Warnings)
+}
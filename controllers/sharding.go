@@ -0,0 +1,170 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+)
+
+// ShardingStrategy decides how the rules of a target are split into shards.
+type ShardingStrategy string
+
+// ShardingLayout decides where the shards of a target are stored.
+type ShardingLayout string
+
+const (
+	// ShardingNone renders all rules into a single entry
+	ShardingNone ShardingStrategy = "none"
+	// ShardingNamespace renders the rules of every namespace into their own shard
+	ShardingNamespace ShardingStrategy = "namespace"
+	// ShardingSize fills shards in rendering order until they reach Sharding.MaxSize
+	ShardingSize ShardingStrategy = "size"
+	// ShardingHash spreads rules over Sharding.Buckets shards by the hash of their ID
+	ShardingHash ShardingStrategy = "hash"
+
+	// ShardingLayoutKeys stores all shards as separate keys of the target ConfigMap
+	ShardingLayoutKeys ShardingLayout = "keys"
+	// ShardingLayoutConfigMaps stores every shard in its own ConfigMap named after the target
+	ShardingLayoutConfigMaps ShardingLayout = "configmaps"
+
+	// DefaultShardMaxSize keeps shards well below the 1 MiB limit of a ConfigMap
+	DefaultShardMaxSize = 512 * 1024
+	// DefaultShardBuckets is the default number of buckets for ShardingHash
+	DefaultShardBuckets = 4
+
+	// clusterRulesShard is the shard of ClusterRules with ShardingNamespace. Namespaces can't contain dots, so it can't clash with one.
+	clusterRulesShard = "cluster.rules"
+)
+
+// Sharding configures how ConfigMapOperator splits rendered rules.
+type Sharding struct {
+	Strategy ShardingStrategy
+	Layout   ShardingLayout
+	// MaxSize is the approximate size in bytes a shard may grow to with ShardingSize
+	MaxSize int
+	// Buckets is the number of shards with ShardingHash
+	Buckets int
+}
+
+// shard is a named subset of the rules of a target.
+type shard struct {
+	name  string
+	rules oathkeeperv1alpha1.RuleList
+}
+
+// Enabled tells whether rules are split into more than one entry.
+func (s Sharding) Enabled() bool {
+	return s.Strategy != "" && s.Strategy != ShardingNone
+}
+
+// Validate checks the configuration for unknown strategies and layouts.
+func (s Sharding) Validate() error {
+	switch s.Strategy {
+	case "", ShardingNone, ShardingNamespace:
+	case ShardingSize:
+		if s.MaxSize <= 0 {
+			return fmt.Errorf("sharding by size needs a positive max size, got %d", s.MaxSize)
+		}
+	case ShardingHash:
+		if s.Buckets <= 0 {
+			return fmt.Errorf("sharding by hash needs a positive number of buckets, got %d", s.Buckets)
+		}
+	default:
+		return fmt.Errorf("unknown sharding strategy: %s", s.Strategy)
+	}
+
+	switch s.Layout {
+	case "", ShardingLayoutKeys, ShardingLayoutConfigMaps:
+		return nil
+	default:
+		return fmt.Errorf("unknown sharding layout: %s", s.Layout)
+	}
+}
+
+// split partitions the rules according to the strategy. Shards are returned in a stable order.
+func (s Sharding) split(rules oathkeeperv1alpha1.RuleList) ([]shard, error) {
+	switch s.Strategy {
+	case ShardingNamespace:
+		return splitByNamespace(rules), nil
+	case ShardingSize:
+		return splitBySize(rules, s.MaxSize)
+	case ShardingHash:
+		return splitByHash(rules, s.Buckets), nil
+	default:
+		return []shard{{rules: rules}}, nil
+	}
+}
+
+func splitByNamespace(rules oathkeeperv1alpha1.RuleList) []shard {
+	byNamespace := map[string][]oathkeeperv1alpha1.Rule{}
+	for _, rule := range rules.Items {
+		byNamespace[rule.Namespace] = append(byNamespace[rule.Namespace], rule)
+	}
+
+	names := make([]string, 0, len(byNamespace))
+	for ns := range byNamespace {
+		names = append(names, ns)
+	}
+	sort.Strings(names)
+
+	shards := make([]shard, 0, len(names))
+	for _, ns := range names {
+		name := ns
+		if ns == "" {
+			name = clusterRulesShard
+		}
+		shards = append(shards, shard{name: name, rules: oathkeeperv1alpha1.RuleList{Items: byNamespace[ns]}})
+	}
+	return shards
+}
+
+// splitBySize packs rules in rendering order. The size of a shard is estimated from the size of its rules rendered one by one,
+// a single rule bigger than maxSize ends up in a shard of its own.
+func splitBySize(rules oathkeeperv1alpha1.RuleList, maxSize int) ([]shard, error) {
+	var shards []shard
+	var current []oathkeeperv1alpha1.Rule
+	size := 0
+
+	flush := func() {
+		shards = append(shards, shard{name: strconv.Itoa(len(shards)), rules: oathkeeperv1alpha1.RuleList{Items: current}})
+		current, size = nil, 0
+	}
+
+	for _, rule := range rules.Sorted().Items {
+		rendered, err := oathkeeperv1alpha1.RuleList{Items: []oathkeeperv1alpha1.Rule{rule}}.ToOathkeeperRules()
+		if err != nil {
+			return nil, err
+		}
+		if len(current) > 0 && size+len(rendered) > maxSize {
+			flush()
+		}
+		current = append(current, rule)
+		size += len(rendered)
+	}
+
+	if len(current) > 0 || len(shards) == 0 {
+		flush()
+	}
+	return shards, nil
+}
+
+func splitByHash(rules oathkeeperv1alpha1.RuleList, buckets int) []shard {
+	shards := make([]shard, buckets)
+	for i := range shards {
+		shards[i] = shard{name: strconv.Itoa(i), rules: oathkeeperv1alpha1.RuleList{Items: []oathkeeperv1alpha1.Rule{}}}
+	}
+
+	for _, rule := range rules.Items {
+		h := fnv.New32a()
+		h.Write([]byte(rule.ToRuleJSON().ID))
+		i := h.Sum32() % uint32(buckets)
+		shards[i].rules.Items = append(shards[i].rules.Items, rule)
+	}
+	return shards
+}
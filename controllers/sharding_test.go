@@ -0,0 +1,157 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+func TestShardingSplit(t *testing.T) {
+
+	rules := oathkeeperv1alpha1.RuleList{Items: []oathkeeperv1alpha1.Rule{
+		newValidRule("r1", "b"),
+		newValidRule("r2", "a"),
+		newValidRule("r3", "b"),
+	}}
+
+	t.Run("should split by namespace in a stable order", func(t *testing.T) {
+
+		//when
+		shards, err := Sharding{Strategy: ShardingNamespace}.split(rules)
+
+		//then
+		require.NoError(t, err)
+		require.Len(t, shards, 2)
+		assert.Equal(t, "a", shards[0].name)
+		assert.Len(t, shards[0].rules.Items, 1)
+		assert.Equal(t, "b", shards[1].name)
+		assert.Len(t, shards[1].rules.Items, 2)
+	})
+
+	t.Run("should start a new shard once the max size is reached", func(t *testing.T) {
+
+		//when
+		shards, err := Sharding{Strategy: ShardingSize, MaxSize: 1}.split(rules)
+
+		//then
+		require.NoError(t, err)
+		require.Len(t, shards, 3)
+		for i, s := range shards {
+			assert.Len(t, s.rules.Items, 1, "shard %d", i)
+		}
+	})
+
+	t.Run("should emit every hash bucket", func(t *testing.T) {
+
+		//when
+		shards, err := Sharding{Strategy: ShardingHash, Buckets: 8}.split(rules)
+
+		//then
+		require.NoError(t, err)
+		require.Len(t, shards, 8)
+		total := 0
+		for _, s := range shards {
+			total += len(s.rules.Items)
+		}
+		assert.Equal(t, 3, total)
+	})
+
+	t.Run("should reject unknown strategies and layouts", func(t *testing.T) {
+		assert.Error(t, Sharding{Strategy: "random"}.Validate())
+		assert.Error(t, Sharding{Strategy: ShardingNamespace, Layout: "files"}.Validate())
+		assert.Error(t, Sharding{Strategy: ShardingSize}.Validate())
+		assert.NoError(t, Sharding{Strategy: ShardingHash, Buckets: 2, Layout: ShardingLayoutConfigMaps}.Validate())
+	})
+}
+
+func TestConfigMapOperatorSharding(t *testing.T) {
+
+	ctx := context.Background()
+	rules := oathkeeperv1alpha1.RuleList{Items: []oathkeeperv1alpha1.Rule{
+		newValidRule("r1", "a"),
+		newValidRule("r2", "b"),
+	}}
+
+	t.Run("should write shards as keys next to an index", func(t *testing.T) {
+
+		//given
+		reconciler, _ := newConfigMapReconciler(t)
+		operator := reconciler.ConfigMapOperator
+		operator.Sharding = Sharding{Strategy: ShardingNamespace, Layout: ShardingLayoutKeys}
+
+		//when
+		require.NoError(t, operator.CreateOrUpdate(ctx, rules, nil))
+
+		//then
+		var actual apiv1.ConfigMap
+		require.NoError(t, operator.Get(ctx, defaultConfigMap, &actual))
+		assert.Contains(t, actual.Data, "access-rules-a.json")
+		assert.Contains(t, actual.Data, "access-rules-b.json")
+		assert.NotContains(t, actual.Data, "access-rules.json")
+
+		var index []ShardIndexEntry
+		require.NoError(t, json.Unmarshal([]byte(actual.Data["access-rules.index.json"]), &index))
+		assert.Equal(t, []ShardIndexEntry{
+			{ConfigMap: defaultConfigMap.Name, Key: "access-rules-a.json", Rules: 1},
+			{ConfigMap: defaultConfigMap.Name, Key: "access-rules-b.json", Rules: 1},
+		}, index)
+	})
+
+	t.Run("should write shards as ConfigMaps and delete stale ones", func(t *testing.T) {
+
+		//given
+		reconciler, _ := newConfigMapReconciler(t)
+		operator := reconciler.ConfigMapOperator
+		operator.Sharding = Sharding{Strategy: ShardingNamespace, Layout: ShardingLayoutConfigMaps}
+		require.NoError(t, operator.CreateOrUpdate(ctx, rules, nil))
+
+		shardB := types.NamespacedName{Name: defaultConfigMap.Name + "-b", Namespace: defaultConfigMap.Namespace}
+		var actual apiv1.ConfigMap
+		require.NoError(t, operator.Get(ctx, shardB, &actual))
+		assert.Equal(t, defaultConfigMap.Name, actual.Labels[ShardOfLabel])
+		assert.Contains(t, actual.Data, "access-rules.json")
+
+		//when
+		require.NoError(t, operator.CreateOrUpdate(ctx, oathkeeperv1alpha1.RuleList{Items: rules.Items[:1]}, nil))
+
+		//then
+		assert.Error(t, operator.Get(ctx, shardB, &actual))
+		shardA := types.NamespacedName{Name: defaultConfigMap.Name + "-a", Namespace: defaultConfigMap.Namespace}
+		assert.NoError(t, operator.Get(ctx, shardA, &actual))
+	})
+
+	t.Run("should restore a shard ConfigMap edited by hand", func(t *testing.T) {
+
+		//given
+		r1, r2 := rules.Items[0], rules.Items[1]
+		reconciler, recorder := newConfigMapReconciler(t, &r1, &r2)
+		reconciler.Sharding = Sharding{Strategy: ShardingNamespace, Layout: ShardingLayoutConfigMaps}
+		require.NoError(t, reconciler.CreateOrUpdate(ctx, rules, nil))
+
+		shardA := types.NamespacedName{Name: defaultConfigMap.Name + "-a", Namespace: defaultConfigMap.Namespace}
+		var shard apiv1.ConfigMap
+		require.NoError(t, reconciler.Get(ctx, shardA, &shard))
+		expected := shard.Data["access-rules.json"]
+		shard.Data["access-rules.json"] = "[]"
+		require.NoError(t, reconciler.Update(ctx, &shard))
+
+		//when
+		_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: defaultConfigMap})
+
+		//then
+		require.NoError(t, err)
+		require.NoError(t, reconciler.Get(ctx, shardA, &shard))
+		assert.Equal(t, expected, shard.Data["access-rules.json"])
+		require.Len(t, recorder.Events, 1)
+	})
+}
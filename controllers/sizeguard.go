@@ -0,0 +1,156 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// DefaultMaxConfigMapSize is the size limit the API server enforces on the data of a ConfigMap
+	DefaultMaxConfigMapSize = 1024 * 1024
+	// DefaultWarnConfigMapSize is the default soft threshold above which writes are reported
+	DefaultWarnConfigMapSize = 768 * 1024
+
+	// ReasonTargetSizeWarning is the reason of Events emitted when a ConfigMap grows above the soft threshold
+	ReasonTargetSizeWarning = "TargetSizeWarning"
+	// ReasonTargetTooLarge is the reason of Events and Rule statuses when a ConfigMap would exceed the hard limit
+	ReasonTargetTooLarge = "TargetTooLarge"
+)
+
+// SizeLimits configures the pre-flight size check of rendered ConfigMaps. A zero value disables the respective check.
+type SizeLimits struct {
+	// Warn is the soft threshold in bytes, writes above it are reported but not refused
+	Warn int
+	// Max is the hard limit in bytes, writes above it are refused
+	Max int
+}
+
+// TooLargeError is returned when the rules rendered for a target don't fit into a ConfigMap. The previous content is kept.
+type TooLargeError struct {
+	ConfigMap types.NamespacedName
+	Size      int
+	Limit     int
+	// Offenders are the Rules that pushed the target over the limit
+	Offenders []types.NamespacedName
+	// Foreign is set in merge mode when the rules in the target that maester didn't write exceed the limit without
+	// any Rule, every Rule of the target is an offender then
+	Foreign bool
+}
+
+func (e *TooLargeError) Error() string {
+	msg := fmt.Sprintf("ConfigMap %s would be %d bytes which exceeds the limit of %d bytes", e.ConfigMap, e.Size, e.Limit)
+	if e.Foreign {
+		msg += ", the rules in it that oathkeeper-maester didn't write exceed the limit on their own"
+	}
+	return msg
+}
+
+// configMapSize approximates the size the API server accounts for the data of a ConfigMap
+func configMapSize(data map[string]string) int {
+	size := 0
+	for k, v := range data {
+		size += len(k) + len(v)
+	}
+	return size
+}
+
+// checkSize compares every desired ConfigMap of a target with the size limits
+func (cmo *ConfigMapOperator) checkSize(target types.NamespacedName, rendered *rendering, triggeredBy *oathkeeperv1alpha1.Rule) error {
+
+	desired := rendered.configMaps
+
+	refs := make([]types.NamespacedName, 0, len(desired))
+	for ref := range desired {
+		refs = append(refs, ref)
+	}
+	sort.Slice(refs, func(i, j int) bool { return refs[i].String() < refs[j].String() })
+
+	var tooLarge *TooLargeError
+	for _, ref := range refs {
+		size := configMapSize(desired[ref])
+		configMapSizeBytes.WithLabelValues(ref.Namespace, ref.Name).Set(float64(size))
+
+		switch {
+		case cmo.SizeLimits.Max > 0 && size > cmo.SizeLimits.Max:
+			configMapSizeExceeded.WithLabelValues(ref.Namespace, ref.Name, "hard").Inc()
+			if tooLarge == nil {
+				tooLarge = &TooLargeError{ConfigMap: ref, Size: size, Limit: cmo.SizeLimits.Max}
+			}
+		case cmo.SizeLimits.Warn > 0 && size > cmo.SizeLimits.Warn:
+			configMapSizeExceeded.WithLabelValues(ref.Namespace, ref.Name, "soft").Inc()
+			if cmo.Recorder != nil {
+				configMap := &apiv1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: ref.Name, Namespace: ref.Namespace}}
+				cmo.Recorder.Eventf(configMap, nil, apiv1.EventTypeWarning, ReasonTargetSizeWarning, "Update",
+					"rendered rules are %d bytes, above the threshold of %d bytes", size, cmo.SizeLimits.Warn)
+			}
+		}
+	}
+
+	if tooLarge == nil {
+		return nil
+	}
+
+	tooLarge.Offenders, tooLarge.Foreign = cmo.offenders(target, rendered.rules, rendered.foreign, triggeredBy)
+	return tooLarge
+}
+
+// offenders returns the fewest Rules whose removal brings the target back under the hard limit. The triggering Rule is
+// blamed first, the remaining Rules from the newest to the oldest. The foreign rules are measured like in the size
+// check, if they don't fit even without any Rule every Rule is returned and foreign is set.
+func (cmo *ConfigMapOperator) offenders(target types.NamespacedName, rules oathkeeperv1alpha1.RuleList, foreign []json.RawMessage, triggeredBy *oathkeeperv1alpha1.Rule) ([]types.NamespacedName, bool) {
+
+	candidates := append([]oathkeeperv1alpha1.Rule(nil), rules.Items...)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if triggeredBy != nil {
+			iTriggered := candidates[i].Name == triggeredBy.Name && candidates[i].Namespace == triggeredBy.Namespace
+			jTriggered := candidates[j].Name == triggeredBy.Name && candidates[j].Namespace == triggeredBy.Namespace
+			if iTriggered != jTriggered {
+				return iTriggered
+			}
+		}
+		return candidates[j].CreationTimestamp.Before(&candidates[i].CreationTimestamp)
+	})
+
+	fits := func(k int) bool {
+		desired, err := cmo.mergedConfigMaps(target, oathkeeperv1alpha1.RuleList{Items: candidates[k:]}, foreign)
+		if err != nil {
+			return false
+		}
+		for _, data := range desired {
+			if configMapSize(data) > cmo.SizeLimits.Max {
+				return false
+			}
+		}
+		return true
+	}
+
+	n := sort.Search(len(candidates), fits)
+	offenders := make([]types.NamespacedName, 0, n)
+	for _, rule := range candidates[:n] {
+		offenders = append(offenders, types.NamespacedName{Name: rule.Name, Namespace: rule.Namespace})
+	}
+	return offenders, len(foreign) > 0 && n == len(candidates) && !fits(n)
+}
+
+// tooLargeStatus describes a TooLargeError in the status of an offending Rule
+func tooLargeStatus(err *TooLargeError) *oathkeeperv1alpha1.Sync {
+	msg := fmt.Sprintf("%s, the previous content is kept until %d Rule(s) including this one are removed or shrunk",
+		err.Error(), len(err.Offenders))
+	if err.Foreign {
+		msg = fmt.Sprintf("%s, the previous content is kept until they are removed or shrunk", err.Error())
+	}
+	return &oathkeeperv1alpha1.Sync{
+		Synced: boolPtr(false),
+		Reason: stringPtr(ReasonTargetTooLarge),
+		Error:  stringPtr(msg),
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestConfigMapOperatorSizeLimits(t *testing.T) {
+
+	ctx := context.Background()
+	older := newValidRule("older", "default")
+	older.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+	newer := newValidRule("newer", "default")
+	newer.CreationTimestamp = metav1.NewTime(time.Now())
+	one := oathkeeperv1alpha1.RuleList{Items: []oathkeeperv1alpha1.Rule{older}}
+	both := oathkeeperv1alpha1.RuleList{Items: []oathkeeperv1alpha1.Rule{older, newer}}
+
+	rendered, err := one.ToOathkeeperRules()
+	require.NoError(t, err)
+	oneSize := len("access-rules.json") + len(rendered)
+
+	t.Run("should warn above the soft threshold", func(t *testing.T) {
+
+		//given
+		reconciler, recorder := newConfigMapReconciler(t)
+		operator := reconciler.ConfigMapOperator
+		operator.SizeLimits = SizeLimits{Warn: 1}
+		before := testutil.ToFloat64(configMapSizeExceeded.WithLabelValues(defaultConfigMap.Namespace, defaultConfigMap.Name, "soft"))
+
+		//when
+		require.NoError(t, operator.CreateOrUpdate(ctx, one, nil))
+
+		//then
+		require.Len(t, recorder.Events, 1)
+		assert.Contains(t, <-recorder.Events, ReasonTargetSizeWarning)
+		assert.Equal(t, before+1, testutil.ToFloat64(configMapSizeExceeded.WithLabelValues(defaultConfigMap.Namespace, defaultConfigMap.Name, "soft")))
+		assert.Equal(t, float64(oneSize), testutil.ToFloat64(configMapSizeBytes.WithLabelValues(defaultConfigMap.Namespace, defaultConfigMap.Name)))
+	})
+
+	t.Run("should refuse writes above the hard limit and keep the previous content", func(t *testing.T) {
+
+		//given
+		reconciler, _ := newConfigMapReconciler(t)
+		operator := reconciler.ConfigMapOperator
+		operator.SizeLimits = SizeLimits{Max: oneSize}
+		require.NoError(t, operator.CreateOrUpdate(ctx, one, nil))
+
+		//when
+		err := operator.CreateOrUpdate(ctx, both, nil)
+
+		//then
+		var tooLarge *TooLargeError
+		require.True(t, errors.As(err, &tooLarge))
+		assert.Equal(t, defaultConfigMap, tooLarge.ConfigMap)
+		assert.Equal(t, oneSize, tooLarge.Limit)
+		assert.Equal(t, []types.NamespacedName{{Name: "newer", Namespace: "default"}}, tooLarge.Offenders)
+
+		var actual apiv1.ConfigMap
+		require.NoError(t, operator.Get(ctx, defaultConfigMap, &actual))
+		assert.Equal(t, string(rendered), actual.Data["access-rules.json"])
+	})
+
+	t.Run("should blame the triggering Rule first", func(t *testing.T) {
+
+		//given
+		reconciler, _ := newConfigMapReconciler(t)
+		operator := reconciler.ConfigMapOperator
+		operator.SizeLimits = SizeLimits{Max: oneSize}
+
+		//when
+		err := operator.CreateOrUpdate(ctx, both, &older)
+
+		//then
+		var tooLarge *TooLargeError
+		require.True(t, errors.As(err, &tooLarge))
+		assert.Equal(t, []types.NamespacedName{{Name: "older", Namespace: "default"}}, tooLarge.Offenders)
+	})
+
+	t.Run("should measure the foreign rules in merge mode when blaming Rules", func(t *testing.T) {
+
+		//given
+		// the foreign rule is larger than a Rule, so both Rules fit without it
+		configMap := newManagedConfigMap(defaultConfigMap, `[{"id": "hand-made", "match": {"url": "http://legacy/`+strings.Repeat("a", 2*oneSize)+`"}}]`)
+		configMap.Data[managedRuleIDsKey("access-rules.json")] = ""
+		reconciler, _ := newConfigMapReconciler(t, configMap)
+		operator := reconciler.ConfigMapOperator
+		operator.Merge = true
+		merged, err := operator.render(ctx, defaultConfigMap, one)
+		require.NoError(t, err)
+		operator.SizeLimits = SizeLimits{Max: configMapSize(merged.configMaps[defaultConfigMap])}
+
+		//when
+		err = operator.CreateOrUpdate(ctx, both, nil)
+
+		//then
+		var tooLarge *TooLargeError
+		require.True(t, errors.As(err, &tooLarge))
+		assert.False(t, tooLarge.Foreign)
+		assert.Equal(t, []types.NamespacedName{{Name: "newer", Namespace: "default"}}, tooLarge.Offenders)
+	})
+
+	t.Run("should report foreign rules that exceed the hard limit on their own", func(t *testing.T) {
+
+		//given
+		configMap := newManagedConfigMap(defaultConfigMap, `[{"id": "hand-made", "match": {"url": "http://legacy/<.*>"}}]`)
+		configMap.Data[managedRuleIDsKey("access-rules.json")] = ""
+		reconciler, _ := newConfigMapReconciler(t, configMap)
+		operator := reconciler.ConfigMapOperator
+		operator.Merge = true
+		operator.SizeLimits = SizeLimits{Max: len("access-rules.json")}
+
+		//when
+		err := operator.CreateOrUpdate(ctx, both, nil)
+
+		//then
+		var tooLarge *TooLargeError
+		require.True(t, errors.As(err, &tooLarge))
+		assert.True(t, tooLarge.Foreign)
+		assert.ElementsMatch(t, []types.NamespacedName{{Name: "older", Namespace: "default"}, {Name: "newer", Namespace: "default"}}, tooLarge.Offenders)
+		assert.Contains(t, *tooLargeStatus(tooLarge).Error, "didn't write exceed the limit on their own")
+	})
+
+	t.Run("should count the managed rule IDs in merge mode", func(t *testing.T) {
+
+		//given
+		configMap := newManagedConfigMap(defaultConfigMap, "")
+		configMap.Data[managedRuleIDsKey("access-rules.json")] = ""
+		reconciler, _ := newConfigMapReconciler(t, configMap)
+		operator := reconciler.ConfigMapOperator
+		operator.Merge = true
+		operator.SizeLimits = SizeLimits{Max: oneSize}
+
+		//when
+		err := operator.CreateOrUpdate(ctx, one, nil)
+
+		//then
+		var tooLarge *TooLargeError
+		require.True(t, errors.As(err, &tooLarge))
+		assert.Equal(t, oneSize+len("access-rules.managed-rule-ids")+len("older.default"), tooLarge.Size)
+	})
+}
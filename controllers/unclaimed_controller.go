@@ -0,0 +1,151 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	apiv1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// DefaultUnclaimedGracePeriod is how long a new Rule may wait for an instance to claim it before it is reported
+	DefaultUnclaimedGracePeriod = time.Minute
+	// ReasonUnclaimed is the reason of Events emitted for Rules no instance of maester claims
+	ReasonUnclaimed = "Unclaimed"
+
+	// KindRule, KindRuleSet and KindClusterRule are the rule resources instances of maester claim
+	KindRule        = "Rule"
+	KindRuleSet     = "RuleSet"
+	KindClusterRule = "ClusterRule"
+)
+
+// UnclaimedRuleReconciler reports rule resources of one kind that are claimed by no instance of maester, i.e. whose
+// status.claimedBy stays empty
+type UnclaimedRuleReconciler struct {
+	client.Client
+	Log         logr.Logger
+	Recorder    events.EventRecorder
+	GracePeriod time.Duration
+	// Kind is the rule resource reported, KindRule unless set
+	Kind string
+}
+
+// Reconcile emits an Event for an unclaimed rule resource and updates the number of unclaimed ones of its kind
+func (r *UnclaimedRuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	claims, err := r.claims(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	unclaimed := 0
+	for _, claimedBy := range claims {
+		if claimedBy == nil {
+			unclaimed++
+		}
+	}
+	unclaimedRules.WithLabelValues(r.kind()).Set(float64(unclaimed))
+
+	obj := r.newObject()
+	if err := r.Get(ctx, req.NamespacedName, obj); err != nil {
+		if apierrs.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if claimedBy(obj) != nil || !obj.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	if wait := time.Until(obj.GetCreationTimestamp().Add(r.GracePeriod)); wait > 0 {
+		return ctrl.Result{RequeueAfter: wait}, nil
+	}
+
+	r.Log.Info(fmt.Sprintf("%s %s is not claimed by any instance of oathkeeper-maester", r.kind(), req.NamespacedName))
+	r.Recorder.Eventf(obj, nil, apiv1.EventTypeWarning, ReasonUnclaimed, "Claim",
+		"%s is not claimed by any instance of oathkeeper-maester, check its labels and spec.oathkeeperClass", r.kind())
+
+	return ctrl.Result{}, nil
+}
+
+func (r *UnclaimedRuleReconciler) kind() string {
+	if r.Kind == "" {
+		return KindRule
+	}
+	return r.Kind
+}
+
+func (r *UnclaimedRuleReconciler) newObject() client.Object {
+	switch r.kind() {
+	case KindRuleSet:
+		return &oathkeeperv1alpha1.RuleSet{}
+	case KindClusterRule:
+		return &oathkeeperv1alpha1.ClusterRule{}
+	default:
+		return &oathkeeperv1alpha1.Rule{}
+	}
+}
+
+// claims lists status.claimedBy of every rule resource of the kind
+func (r *UnclaimedRuleReconciler) claims(ctx context.Context) ([]*string, error) {
+	var claims []*string
+	switch r.kind() {
+	case KindRuleSet:
+		var list oathkeeperv1alpha1.RuleSetList
+		if err := r.List(ctx, &list); err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			claims = append(claims, claimedBy(&list.Items[i]))
+		}
+	case KindClusterRule:
+		var list oathkeeperv1alpha1.ClusterRuleList
+		if err := r.List(ctx, &list); err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			claims = append(claims, claimedBy(&list.Items[i]))
+		}
+	default:
+		var list oathkeeperv1alpha1.RuleList
+		if err := r.List(ctx, &list); err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			claims = append(claims, claimedBy(&list.Items[i]))
+		}
+	}
+	return claims, nil
+}
+
+// claimedBy returns status.claimedBy of a Rule, RuleSet or ClusterRule
+func claimedBy(obj client.Object) *string {
+	switch o := obj.(type) {
+	case *oathkeeperv1alpha1.Rule:
+		return o.Status.ClaimedBy
+	case *oathkeeperv1alpha1.RuleSet:
+		return o.Status.ClaimedBy
+	case *oathkeeperv1alpha1.ClusterRule:
+		return o.Status.ClaimedBy
+	}
+	return nil
+}
+
+// SetupWithManager registers the reconciler for all rule resources of the kind
+func (r *UnclaimedRuleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("unclaimed" + strings.ToLower(r.kind())).
+		For(r.newObject()).
+		Complete(r)
+}
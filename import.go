@@ -0,0 +1,69 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ory/oathkeeper-maester/internal/importer"
+	"github.com/ory/oathkeeper-maester/internal/manifests"
+)
+
+// runImport converts Oathkeeper access rule files into Rule manifests and returns the process exit code.
+func runImport(args []string) int {
+	var namespace string
+	var namespaces string
+	var outputPath string
+
+	importCommand := flag.NewFlagSet("import", flag.ExitOnError)
+	importCommand.StringVar(&namespace, "namespace", "default", "Namespace of Rules whose ID doesn't follow the name.namespace scheme.")
+	importCommand.StringVar(&namespaces, "namespaces", "", "Comma-separated namespaces IDs of the name.namespace scheme are split into, in addition to namespace.")
+	importCommand.StringVar(&outputPath, "output", "", "Path of the file to write Rule manifests to. Defaults to stdout.")
+	if err := importCommand.Parse(args); err != nil {
+		setupLog.Error(err, "problem parsing flags")
+		return 2
+	}
+
+	if importCommand.NArg() == 0 {
+		setupLog.Error(fmt.Errorf("no files or directories given"), "nothing to import")
+		return 2
+	}
+
+	docs, err := manifests.Load(importCommand.Args())
+	if err != nil {
+		setupLog.Error(err, "unable to read access rules")
+		return 2
+	}
+
+	result := importer.Convert(docs, namespace, parseList(namespaces))
+
+	var out io.Writer = os.Stdout
+	if outputPath != "" {
+		f, err := os.Create(outputPath)
+		if err != nil {
+			setupLog.Error(err, "unable to create output file")
+			return 2
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if err := importer.WriteManifests(out, result.Rules); err != nil {
+		setupLog.Error(err, "unable to write Rule manifests")
+		return 2
+	}
+
+	for _, issue := range result.Issues {
+		fmt.Fprintln(os.Stderr, issue)
+	}
+	fmt.Fprintf(os.Stderr, "%d rule(s) imported, %d issue(s)\n", len(result.Rules), len(result.Issues))
+
+	if len(result.Issues) != 0 {
+		return 1
+	}
+	return 0
+}
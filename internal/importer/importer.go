@@ -0,0 +1,282 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	"github.com/ory/oathkeeper-maester/internal/manifests"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// Issue describes an Oathkeeper rule that can't be converted into a Rule without losing information.
+type Issue struct {
+	File    string
+	Line    int
+	ID      string
+	Message string
+}
+
+func (i Issue) String() string {
+	if i.ID == "" {
+		return fmt.Sprintf("%s:%d: %s", i.File, i.Line, i.Message)
+	}
+	return fmt.Sprintf("%s:%d: rule %q: %s", i.File, i.Line, i.ID, i.Message)
+}
+
+// Result holds the Rules converted from Oathkeeper access rules along with anything that didn't round-trip.
+type Result struct {
+	Rules  []oathkeeperv1alpha1.Rule
+	Issues []Issue
+}
+
+// Convert turns the Oathkeeper access rules found in docs into Rules. IDs following the name.namespace scheme used by maester
+// are split back into name and namespace when the namespace is defaultNamespace or one of namespaces, all other rules
+// are placed in defaultNamespace and keep their ID. Every rule is rendered back and compared with its source,
+// differences and IDs that only look like they follow the scheme are reported as Issues.
+func Convert(docs []manifests.Document, defaultNamespace string, namespaces []string) Result {
+	var result Result
+	seen := map[string]string{}
+	expected := map[string]bool{defaultNamespace: true}
+	for _, ns := range namespaces {
+		expected[ns] = true
+	}
+
+	for _, doc := range docs {
+		addIssue := func(id, msg string) {
+			result.Issues = append(result.Issues, Issue{File: doc.File, Line: doc.Line, ID: id, Message: msg})
+		}
+
+		items, err := decodeDocument(doc.Raw)
+		if err != nil {
+			addIssue("", err.Error())
+			continue
+		}
+
+		for _, item := range items {
+			var ruleJSON oathkeeperv1alpha1.RuleJSON
+			if err := json.Unmarshal(item, &ruleJSON); err != nil {
+				addIssue("", err.Error())
+				continue
+			}
+
+			if ruleJSON.ID == "" {
+				addIssue("", "rule has no id, skipping")
+				continue
+			}
+
+			rule, ok := toRule(ruleJSON, defaultNamespace, expected)
+			if !ok {
+				addIssue(ruleJSON.ID, "id can't be turned into a Rule name, skipping")
+				continue
+			}
+			if _, namespace, ok := splitID(ruleJSON.ID); ok && !expected[namespace] {
+				addIssue(ruleJSON.ID, fmt.Sprintf("id looks like name.namespace but %s is not an expected namespace, imported into %s with the id kept",
+					namespace, defaultNamespace))
+			}
+
+			ref := rule.Namespace + "/" + rule.Name
+			if prev, ok := seen[ref]; ok {
+				addIssue(ruleJSON.ID, fmt.Sprintf("maps to Rule %s which is already used by rule %q, skipping", ref, prev))
+				continue
+			}
+			seen[ref] = ruleJSON.ID
+
+			diffs, err := roundTrip(item, rule)
+			if err != nil {
+				addIssue(ruleJSON.ID, err.Error())
+				continue
+			}
+			for _, d := range diffs {
+				addIssue(ruleJSON.ID, d)
+			}
+
+			result.Rules = append(result.Rules, rule)
+		}
+	}
+
+	return result
+}
+
+// WriteManifests writes the Rules as a multi-document YAML stream.
+func WriteManifests(w io.Writer, rules []oathkeeperv1alpha1.Rule) error {
+	type metadata struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	}
+	type manifest struct {
+		metav1.TypeMeta `json:",inline"`
+		Metadata        metadata                    `json:"metadata"`
+		Spec            oathkeeperv1alpha1.RuleSpec `json:"spec"`
+	}
+
+	for _, rule := range rules {
+		out, err := yaml.Marshal(manifest{
+			TypeMeta: rule.TypeMeta,
+			Metadata: metadata{Name: rule.Name, Namespace: rule.Namespace},
+			Spec:     rule.Spec,
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "---\n%s", out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeDocument returns the rules in a document holding either a single rule or an array of them.
+func decodeDocument(raw []byte) ([]json.RawMessage, error) {
+	data, err := yaml.YAMLToJSON(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, err
+		}
+		return items, nil
+	}
+	return []json.RawMessage{data}, nil
+}
+
+// splitID splits an ID of the name.namespace scheme used by maester. Other IDs, such as host names, can look the same.
+func splitID(id string) (name, namespace string, ok bool) {
+	name, namespace, ok = oathkeeperv1alpha1.ParseRuleID(id)
+	if !ok || len(k8svalidation.IsDNS1123Label(namespace)) != 0 {
+		return "", "", false
+	}
+	return name, namespace, true
+}
+
+func toRule(ruleJSON oathkeeperv1alpha1.RuleJSON, defaultNamespace string, expected map[string]bool) (oathkeeperv1alpha1.Rule, bool) {
+	name, namespace, ok := splitID(ruleJSON.ID)
+	if !ok || !expected[namespace] {
+		name, namespace = ruleJSON.ID, defaultNamespace
+	}
+	name = sanitizeName(name)
+	if name == "" {
+		return oathkeeperv1alpha1.Rule{}, false
+	}
+
+	spec := ruleJSON.RuleSpec
+	// keep the ID where the Rule would render to a different one, so logs and metrics of Oathkeeper carry on
+	if id := ruleJSON.ID; oathkeeperv1alpha1.RuleID(name, namespace) != id {
+		spec.ID = &id
+	}
+	// rules without upstream at all only serve the decisions API
+	if spec.Upstream == nil {
+		decisionOnly := true
+		spec.DecisionOnly = &decisionOnly
+	}
+	// an empty upstream is what ToRuleJSON renders for Rules without one, but the CRD rejects it
+	if u := spec.Upstream; u != nil && u.URL == "" && u.StripPath == nil && (u.PreserveHost == nil || !*u.PreserveHost) {
+		spec.Upstream = nil
+	}
+
+	return oathkeeperv1alpha1.Rule{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: oathkeeperv1alpha1.GroupVersion.String(),
+			Kind:       "Rule",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: spec,
+	}, true
+}
+
+func sanitizeName(id string) string {
+	name := invalidNameChars.ReplaceAllString(strings.ToLower(id), "-")
+	if len(name) > k8svalidation.DNS1123SubdomainMaxLength {
+		name = name[:k8svalidation.DNS1123SubdomainMaxLength]
+	}
+	return strings.Trim(name, ".-")
+}
+
+// roundTrip renders the rule the way the controller would and describes every difference to the source.
+func roundTrip(source json.RawMessage, rule oathkeeperv1alpha1.Rule) ([]string, error) {
+	rendered, err := json.Marshal(rule.ToRuleJSON())
+	if err != nil {
+		return nil, err
+	}
+
+	var before, after map[string]interface{}
+	if err := json.Unmarshal(source, &before); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(rendered, &after); err != nil {
+		return nil, err
+	}
+
+	var diffs []string
+	diff("", before, after, &diffs)
+	return diffs, nil
+}
+
+func diff(path string, before, after interface{}, diffs *[]string) {
+	b, bOk := before.(map[string]interface{})
+	a, aOk := after.(map[string]interface{})
+	if bOk && aOk {
+		keys := map[string]struct{}{}
+		for k := range b {
+			keys[k] = struct{}{}
+		}
+		for k := range a {
+			keys[k] = struct{}{}
+		}
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+
+		for _, k := range sorted {
+			p := k
+			if path != "" {
+				p = path + "." + k
+			}
+			bv, inBefore := b[k]
+			av, inAfter := a[k]
+			switch {
+			case !inAfter:
+				*diffs = append(*diffs, fmt.Sprintf("%s is dropped", p))
+			case !inBefore:
+				*diffs = append(*diffs, fmt.Sprintf("%s is added as %s", p, compact(av)))
+			default:
+				diff(p, bv, av, diffs)
+			}
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(before, after) {
+		*diffs = append(*diffs, fmt.Sprintf("%s changes from %s to %s", path, compact(before), compact(after)))
+	}
+}
+
+func compact(v interface{}) string {
+	out, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(out)
+}
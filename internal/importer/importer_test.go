@@ -0,0 +1,150 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package importer
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ory/oathkeeper-maester/internal/manifests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const accessRules = `[
+  {
+    "id": "foo.team-a",
+    "upstream": {"url": "http://foo", "strip_path": "/api", "preserve_host": true},
+    "match": {"url": "http://my-app/foo", "methods": ["GET"]},
+    "authenticators": [{"handler": "anonymous"}],
+    "authorizer": {"handler": "allow"},
+    "mutators": [{"handler": "header", "config": {"headers": {"X-User": "{{ print .Subject }}"}}}]
+  },
+  {
+    "id": "Legacy_Rule",
+    "description": "a legacy rule",
+    "match": {"url": "http://my-app/bar", "methods": ["GET"]},
+    "authenticators": [{"handler": "anonymous"}],
+    "mutators": [{"handler": "noop"}]
+  }
+]`
+
+func TestConvert(t *testing.T) {
+
+	//given
+	docs := []manifests.Document{{File: "rules.json", Line: 1, Raw: []byte(accessRules)}}
+
+	//when
+	result := Convert(docs, "imported", []string{"team-a"})
+
+	//then
+	require.Len(t, result.Rules, 2)
+
+	t.Run("should split maester IDs into name and namespace", func(t *testing.T) {
+		rule := result.Rules[0]
+		assert.Equal(t, "foo", rule.Name)
+		assert.Equal(t, "team-a", rule.Namespace)
+		assert.Equal(t, "/api", *rule.Spec.Upstream.StripPath)
+		assert.True(t, *rule.Spec.Upstream.PreserveHost)
+	})
+
+	t.Run("should sanitize other IDs and use the default namespace", func(t *testing.T) {
+		rule := result.Rules[1]
+		assert.Equal(t, "legacy-rule", rule.Name)
+		assert.Equal(t, "imported", rule.Namespace)
+		assert.Equal(t, "Legacy_Rule", *rule.Spec.ID)
+		assert.Nil(t, rule.Spec.Upstream)
+	})
+
+	t.Run("should make rules without upstream decision-only", func(t *testing.T) {
+		assert.Nil(t, result.Rules[0].Spec.DecisionOnly)
+		assert.True(t, *result.Rules[1].Spec.DecisionOnly)
+	})
+
+	t.Run("should report everything that doesn't round-trip", func(t *testing.T) {
+		var messages []string
+		for _, issue := range result.Issues {
+			assert.Equal(t, "Legacy_Rule", issue.ID)
+			messages = append(messages, issue.Message)
+		}
+		assert.Equal(t, []string{
+			`authorizer is added as {"handler":"deny"}`,
+			"description is dropped",
+		}, messages)
+	})
+}
+
+func TestConvertUnexpectedNamespaces(t *testing.T) {
+
+	//given
+	docs := []manifests.Document{{File: "rules.json", Line: 1, Raw: []byte(`[
+  {"id": "api.example.com", "upstream": {"url": "http://api", "preserve_host": false}, "match": {"url": "http://api.example.com/", "methods": ["GET"]},
+   "authenticators": [{"handler": "anonymous"}], "authorizer": {"handler": "allow"}, "mutators": [{"handler": "noop"}]},
+  {"id": "bar.imported", "upstream": {"url": "http://bar", "preserve_host": false}, "match": {"url": "http://my-app/bar", "methods": ["GET"]},
+   "authenticators": [{"handler": "anonymous"}], "authorizer": {"handler": "allow"}, "mutators": [{"handler": "noop"}]}
+]`)}}
+
+	//when
+	result := Convert(docs, "imported", nil)
+
+	//then
+	require.Len(t, result.Rules, 2)
+
+	t.Run("should keep IDs with an unexpected namespace in the default namespace", func(t *testing.T) {
+		rule := result.Rules[0]
+		assert.Equal(t, "api.example.com", rule.Name)
+		assert.Equal(t, "imported", rule.Namespace)
+		assert.Equal(t, "api.example.com", *rule.Spec.ID)
+	})
+
+	t.Run("should split IDs in the default namespace", func(t *testing.T) {
+		rule := result.Rules[1]
+		assert.Equal(t, "bar", rule.Name)
+		assert.Equal(t, "imported", rule.Namespace)
+		assert.Nil(t, rule.Spec.ID)
+	})
+
+	t.Run("should report the guessed split", func(t *testing.T) {
+		require.Len(t, result.Issues, 1)
+		assert.Equal(t, "api.example.com", result.Issues[0].ID)
+		assert.Contains(t, result.Issues[0].Message, "com is not an expected namespace")
+	})
+}
+
+func TestWriteManifests(t *testing.T) {
+
+	//given
+	result := Convert([]manifests.Document{{File: "rules.json", Line: 1, Raw: []byte(accessRules)}}, "imported", []string{"team-a"})
+	var out bytes.Buffer
+
+	//when
+	require.NoError(t, WriteManifests(&out, result.Rules[:1]))
+
+	//then
+	assert.Equal(t, `---
+apiVersion: oathkeeper.ory.sh/v1alpha1
+kind: Rule
+metadata:
+  name: foo
+  namespace: team-a
+spec:
+  authenticators:
+  - handler: anonymous
+  authorizer:
+    handler: allow
+  match:
+    methods:
+    - GET
+    url: http://my-app/foo
+  mutators:
+  - config:
+      headers:
+        X-User: '{{ print .Subject }}'
+    handler: header
+  upstream:
+    preserveHost: true
+    stripPath: /api
+    url: http://foo
+`, out.String())
+}
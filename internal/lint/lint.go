@@ -0,0 +1,252 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	"github.com/ory/oathkeeper-maester/internal/manifests"
+	"github.com/ory/oathkeeper-maester/internal/validation"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// SeverityError marks findings that make a manifest unusable.
+	SeverityError = "error"
+	// SeverityWarning marks findings that should be fixed but don't make a manifest unusable.
+	SeverityWarning = "warning"
+
+	// mirrors the constraints on Upstream.URL in the CRD schema
+	upstreamURLMinLength = 3
+	upstreamURLMaxLength = 256
+)
+
+var upstreamURLPattern = regexp.MustCompile(`^(?:https?:\/\/)?(?:[^@\/\n]+@)?(?:www\.)?([^:\/\n]+)`)
+
+// Finding is a single problem detected in a manifest.
+type Finding struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Rule     string `json:"rule,omitempty"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// Report summarizes the result of linting a set of manifests.
+type Report struct {
+	Valid    bool      `json:"valid"`
+	Rules    int       `json:"rules"`
+	Findings []Finding `json:"findings"`
+}
+
+// Run lints the given rules and decode errors. Every rule is checked against the schema constraints enforced by the API server
+// and against the handler allow-lists in config.
+func Run(rules []manifests.SourcedRule, decodeErrs []*manifests.DecodeError, config validation.Config) Report {
+	report := Report{Rules: len(rules), Findings: []Finding{}}
+
+	for _, err := range decodeErrs {
+		report.Findings = append(report.Findings, Finding{
+			File:     err.File,
+			Line:     err.Line,
+			Severity: SeverityError,
+			Message:  err.Err.Error(),
+		})
+	}
+
+	seen := map[string]manifests.SourcedRule{}
+	for _, rule := range rules {
+		ref := rule.Namespace + "/" + rule.Name
+		addError := func(msg string) {
+			report.Findings = append(report.Findings, Finding{
+				File:     rule.File,
+				Line:     rule.Line,
+				Rule:     ref,
+				Severity: SeverityError,
+				Message:  msg,
+			})
+		}
+
+		if prev, ok := seen[ref]; ok {
+			addError(fmt.Sprintf("duplicate Rule, already defined at %s:%d", prev.File, prev.Line))
+		} else {
+			seen[ref] = rule
+		}
+
+		for _, msg := range checkStructure(rule.Rule) {
+			addError(msg)
+		}
+
+		// ValidateWith dereferences every handler, so it can only run once they are all present
+		if handlersPresent(rule.Rule) {
+			if err := rule.ValidateWith(config); err != nil {
+				addError(err.Error())
+			}
+			for _, warning := range rule.DeprecationWarnings(config) {
+				report.Findings = append(report.Findings, Finding{
+					File:     rule.File,
+					Line:     rule.Line,
+					Rule:     ref,
+					Severity: SeverityWarning,
+					Message:  warning,
+				})
+			}
+		}
+	}
+
+	report.Valid = !slices.ContainsFunc(report.Findings, func(f Finding) bool { return f.Severity == SeverityError })
+	return report
+}
+
+// WriteJSON writes the report as an indented JSON document.
+func (r Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteGitHub writes the report as GitHub Actions workflow commands, so findings show up as annotations on pull requests.
+func (r Report) WriteGitHub(w io.Writer) error {
+	for _, f := range r.Findings {
+		msg := f.Message
+		if f.Rule != "" {
+			msg = fmt.Sprintf("Rule %s: %s", f.Rule, msg)
+		}
+		if _, err := fmt.Fprintf(w, "::%s file=%s,line=%d::%s\n", f.Severity, escapeProperty(f.File), f.Line, escapeData(msg)); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "%d rule(s) checked, %d finding(s)\n", r.Rules, len(r.Findings))
+	return err
+}
+
+func checkStructure(rule oathkeeperv1alpha1.Rule) []string {
+	var msgs []string
+
+	if rule.Name == "" {
+		msgs = append(msgs, "metadata.name is required")
+	}
+
+	switch {
+	case rule.Spec.Match == nil && len(rule.Spec.Matches) == 0:
+		msgs = append(msgs, "spec.match or spec.matches is required")
+	case rule.Spec.Match != nil && len(rule.Spec.Matches) != 0:
+		msgs = append(msgs, "only one of spec.match and spec.matches may be set")
+	case rule.Spec.Match != nil && rule.Spec.Match.GRPC != nil:
+		if rule.Spec.Match.URL != "" || len(rule.Spec.Match.Methods) != 0 {
+			msgs = append(msgs, "spec.match.url and spec.match.methods can't be combined with spec.match.grpc")
+		}
+		if rule.Spec.Match.GRPC.Authority == "" {
+			msgs = append(msgs, "spec.match.grpc.authority is required")
+		}
+		if rule.Spec.Match.GRPC.FullMethod == "" {
+			msgs = append(msgs, "spec.match.grpc.fullMethod is required")
+		}
+	case rule.Spec.Match != nil:
+		if rule.Spec.Match.URL == "" {
+			msgs = append(msgs, "spec.match.url is required")
+		}
+		if len(rule.Spec.Match.Methods) == 0 {
+			msgs = append(msgs, "spec.match.methods is required")
+		}
+	}
+
+	if u := rule.Spec.Upstream; u != nil {
+		switch {
+		case len(u.URL) < upstreamURLMinLength || len(u.URL) > upstreamURLMaxLength:
+			msgs = append(msgs, fmt.Sprintf("spec.upstream.url must be between %d and %d characters long", upstreamURLMinLength, upstreamURLMaxLength))
+		case !upstreamURLPattern.MatchString(u.URL):
+			msgs = append(msgs, fmt.Sprintf("spec.upstream.url %q is not a valid URL", u.URL))
+		}
+	}
+
+	if name := rule.Spec.ConfigMapName; name != nil {
+		for _, e := range k8svalidation.IsDNS1123Subdomain(*name) {
+			msgs = append(msgs, fmt.Sprintf("spec.configMapName: %s", e))
+		}
+	}
+
+	for _, h := range handlersOf(rule) {
+		msgs = append(msgs, checkHandler(h.path, h.Handler)...)
+	}
+
+	return msgs
+}
+
+type namedHandler struct {
+	*oathkeeperv1alpha1.Handler
+	path string
+}
+
+// handlersOf returns all handlers of a rule along with their path in the spec.
+func handlersOf(rule oathkeeperv1alpha1.Rule) []namedHandler {
+	var handlers []namedHandler
+	for i, a := range rule.Spec.Authenticators {
+		h := namedHandler{path: fmt.Sprintf("spec.authenticators[%d]", i)}
+		if a != nil {
+			h.Handler = a.Handler
+		}
+		handlers = append(handlers, h)
+	}
+	if a := rule.Spec.Authorizer; a != nil {
+		handlers = append(handlers, namedHandler{Handler: a.Handler, path: "spec.authorizer"})
+	}
+	for i, m := range rule.Spec.Mutators {
+		h := namedHandler{path: fmt.Sprintf("spec.mutators[%d]", i)}
+		if m != nil {
+			h.Handler = m.Handler
+		}
+		handlers = append(handlers, h)
+	}
+	for i, e := range rule.Spec.Errors {
+		h := namedHandler{path: fmt.Sprintf("spec.errors[%d]", i)}
+		if e != nil {
+			h.Handler = e.Handler
+		}
+		handlers = append(handlers, h)
+	}
+	return handlers
+}
+
+func handlersPresent(rule oathkeeperv1alpha1.Rule) bool {
+	for _, h := range handlersOf(rule) {
+		if h.Handler == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func checkHandler(path string, h *oathkeeperv1alpha1.Handler) []string {
+	if h != nil && h.PresetRef != nil {
+		if h.Name != "" {
+			return []string{fmt.Sprintf("%s: handler and presetRef can't both be set", path)}
+		}
+		if h.PresetRef.Name == "" {
+			return []string{fmt.Sprintf("%s.presetRef.name is required", path)}
+		}
+	} else if h == nil || h.Name == "" {
+		return []string{fmt.Sprintf("%s.handler is required", path)}
+	}
+	if h.Config != nil && len(h.Config.Raw) > 0 {
+		var config map[string]interface{}
+		if err := json.Unmarshal(h.Config.Raw, &config); err != nil {
+			return []string{fmt.Sprintf("%s.config must be an object", path)}
+		}
+	}
+	return nil
+}
+
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+func escapeProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
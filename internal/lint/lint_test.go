@@ -0,0 +1,170 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package lint
+
+import (
+	"bytes"
+	"testing"
+
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	"github.com/ory/oathkeeper-maester/internal/manifests"
+	"github.com/ory/oathkeeper-maester/internal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var validationConfig = validation.Config{
+	AuthenticatorsAvailable: []string{"anonymous"},
+	AuthorizersAvailable:    []string{"allow"},
+	MutatorsAvailable:       []string{"noop"},
+}
+
+func TestRun(t *testing.T) {
+
+	t.Run("should accept a valid rule", func(t *testing.T) {
+
+		//when
+		report := Run([]manifests.SourcedRule{newSourcedRule("r1", "allow")}, nil, validationConfig)
+
+		//then
+		assert.True(t, report.Valid)
+		assert.Equal(t, 1, report.Rules)
+		assert.Empty(t, report.Findings)
+	})
+
+	t.Run("should report structural and handler errors", func(t *testing.T) {
+
+		//given
+		rule := newSourcedRule("r1", "deny")
+		rule.Spec.Match.Methods = nil
+
+		//when
+		report := Run([]manifests.SourcedRule{rule}, nil, validationConfig)
+
+		//then
+		assert.False(t, report.Valid)
+		require.Len(t, report.Findings, 2)
+		assert.Equal(t, "spec.match.methods is required", report.Findings[0].Message)
+		assert.Contains(t, report.Findings[1].Message, "authorizer/deny")
+		assert.Equal(t, "default/r1", report.Findings[1].Rule)
+	})
+
+	t.Run("should report duplicate rules", func(t *testing.T) {
+
+		//when
+		report := Run([]manifests.SourcedRule{newSourcedRule("r1", "allow"), newSourcedRule("r1", "allow")}, nil, validationConfig)
+
+		//then
+		require.Len(t, report.Findings, 1)
+		assert.Contains(t, report.Findings[0].Message, "duplicate Rule")
+	})
+
+	t.Run("should not panic on missing handler names", func(t *testing.T) {
+
+		//given
+		rule := newSourcedRule("r1", "allow")
+		rule.Spec.Authorizer = &oathkeeperv1alpha1.Authorizer{}
+
+		//when
+		report := Run([]manifests.SourcedRule{rule}, nil, validationConfig)
+
+		//then
+		require.Len(t, report.Findings, 1)
+		assert.Equal(t, "spec.authorizer.handler is required", report.Findings[0].Message)
+	})
+
+	t.Run("should accept handlers referencing a preset", func(t *testing.T) {
+
+		//given
+		rule := newSourcedRule("r1", "allow")
+		rule.Spec.Authorizer = &oathkeeperv1alpha1.Authorizer{Handler: &oathkeeperv1alpha1.Handler{PresetRef: &oathkeeperv1alpha1.PresetRef{Name: "keto"}}}
+
+		//when
+		report := Run([]manifests.SourcedRule{rule}, nil, validationConfig)
+
+		//then
+		assert.Empty(t, report.Findings)
+	})
+
+	t.Run("should report overlapping matches", func(t *testing.T) {
+
+		//given
+		rule := newSourcedRule("r1", "allow")
+		rule.Spec.Matches = []*oathkeeperv1alpha1.Match{rule.Spec.Match, rule.Spec.Match}
+		rule.Spec.Match = nil
+
+		//when
+		report := Run([]manifests.SourcedRule{rule}, nil, validationConfig)
+
+		//then
+		require.Len(t, report.Findings, 1)
+		assert.Contains(t, report.Findings[0].Message, "matches[1]: overlaps with matches[0]")
+	})
+
+	t.Run("should report incomplete gRPC matches and gRPC matches the Oathkeeper version lacks", func(t *testing.T) {
+
+		//given
+		complete := newSourcedRule("r1", "allow")
+		complete.Spec.Match = &oathkeeperv1alpha1.Match{GRPC: &oathkeeperv1alpha1.GRPCMatch{Authority: "my-app", FullMethod: "my.Service/Get"}}
+		incomplete := newSourcedRule("r2", "allow")
+		incomplete.Spec.Match = &oathkeeperv1alpha1.Match{GRPC: &oathkeeperv1alpha1.GRPCMatch{Authority: "my-app"}}
+
+		//when
+		report := Run([]manifests.SourcedRule{complete, incomplete}, nil, validationConfig)
+
+		//then
+		require.Len(t, report.Findings, 3)
+		assert.Contains(t, report.Findings[0].Message, "gRPC matches need Oathkeeper v0.40.0 or newer")
+		assert.Equal(t, "default/r2", report.Findings[1].Rule)
+		assert.Equal(t, "spec.match.grpc.fullMethod is required", report.Findings[1].Message)
+	})
+
+	t.Run("should report deprecated handlers as warnings", func(t *testing.T) {
+
+		//given
+		config := validationConfig
+		config.AuthorizersAvailable = []string{"keto_engine_acp_ory"}
+		config.Deprecated = validation.Deprecations
+
+		//when
+		report := Run([]manifests.SourcedRule{newSourcedRule("r1", "keto_engine_acp_ory")}, nil, config)
+
+		//then
+		assert.True(t, report.Valid)
+		require.Len(t, report.Findings, 1)
+		assert.Equal(t, SeverityWarning, report.Findings[0].Severity)
+		assert.Equal(t, "authorizer keto_engine_acp_ory is deprecated, use remote_json instead", report.Findings[0].Message)
+	})
+}
+
+func TestWriteGitHub(t *testing.T) {
+
+	//given
+	report := Report{Rules: 1, Findings: []Finding{{File: "a,b.yaml", Line: 3, Rule: "default/r1", Severity: SeverityError, Message: "50%\nbad"}}}
+	var out bytes.Buffer
+
+	//when
+	require.NoError(t, report.WriteGitHub(&out))
+
+	//then
+	assert.Equal(t, "::error file=a%2Cb.yaml,line=3::Rule default/r1: 50%25%0Abad\n1 rule(s) checked, 1 finding(s)\n", out.String())
+}
+
+func newSourcedRule(name, authorizer string) manifests.SourcedRule {
+	return manifests.SourcedRule{
+		File: "rules.yaml",
+		Line: 1,
+		Rule: oathkeeperv1alpha1.Rule{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: oathkeeperv1alpha1.RuleSpec{
+				Match: &oathkeeperv1alpha1.Match{
+					URL:     "http://my-app/some-route",
+					Methods: []string{"GET"},
+				},
+				Authorizer: &oathkeeperv1alpha1.Authorizer{Handler: &oathkeeperv1alpha1.Handler{Name: authorizer}},
+			},
+		},
+	}
+}
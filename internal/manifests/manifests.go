@@ -0,0 +1,218 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package manifests
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	ruleKind        = "Rule"
+	ruleSetKind     = "RuleSet"
+	clusterRuleKind = "ClusterRule"
+)
+
+// Document is a single YAML or JSON document read from a file on disk.
+type Document struct {
+	// File is the path of the file the document was read from
+	File string
+	// Line is the 1-based line number the document starts at
+	Line int
+	// Raw holds the document body
+	Raw []byte
+}
+
+// SourcedRule is a Rule decoded from a manifest along with its location on disk. Every route of a RuleSet and every
+// ClusterRule is decoded as the Rule it is rendered as.
+type SourcedRule struct {
+	oathkeeperv1alpha1.Rule
+	File string
+	Line int
+}
+
+// DecodeError is returned when a document could not be decoded into a Rule.
+type DecodeError struct {
+	File string
+	Line int
+	Err  error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Err)
+}
+
+// Load reads all YAML and JSON documents from the given files and directories. Directories are walked recursively.
+func Load(paths []string) ([]Document, error) {
+	var docs []Document
+	for _, p := range paths {
+		err := filepath.Walk(p, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() || (path != p && !isManifestFile(path)) {
+				return nil
+			}
+			fileDocs, err := loadFile(path)
+			if err != nil {
+				return err
+			}
+			docs = append(docs, fileDocs...)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return docs, nil
+}
+
+type typeMeta struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+}
+
+// DecodeRules decodes Rule, RuleSet and ClusterRule objects out of the provided documents. RuleSets are expanded into
+// the Rules of their routes. Documents of other kinds are skipped, lists are flattened. Documents that fail to decode
+// are returned as DecodeErrors rather than aborting the whole run.
+func DecodeRules(docs []Document) ([]SourcedRule, []*DecodeError) {
+	var rules []SourcedRule
+	var errs []*DecodeError
+
+	for _, doc := range docs {
+		var meta struct {
+			typeMeta
+			Items []json.RawMessage `json:"items"`
+		}
+		raw, err := yaml.YAMLToJSON(doc.Raw)
+		if err == nil {
+			err = json.Unmarshal(raw, &meta)
+		}
+		if err != nil {
+			errs = append(errs, &DecodeError{File: doc.File, Line: doc.Line, Err: err})
+			continue
+		}
+
+		items := []json.RawMessage{raw}
+		if kind, isList := strings.CutSuffix(meta.Kind, "List"); isList && (kind == "" || isLintable(meta.APIVersion, kind)) {
+			items = meta.Items
+		} else if !isLintable(meta.APIVersion, meta.Kind) {
+			continue
+		}
+
+		for _, item := range items {
+			decoded, err := decode(item)
+			if err != nil {
+				errs = append(errs, &DecodeError{File: doc.File, Line: doc.Line, Err: err})
+				continue
+			}
+			for _, rule := range decoded {
+				rules = append(rules, SourcedRule{Rule: rule, File: doc.File, Line: doc.Line})
+			}
+		}
+	}
+
+	return rules, errs
+}
+
+// decode returns the Rules an object is rendered as, or none if it is of another kind
+func decode(item json.RawMessage) ([]oathkeeperv1alpha1.Rule, error) {
+	var meta typeMeta
+	if err := json.Unmarshal(item, &meta); err != nil {
+		return nil, err
+	}
+	if !isLintable(meta.APIVersion, meta.Kind) {
+		return nil, nil
+	}
+
+	switch meta.Kind {
+	case ruleSetKind:
+		var ruleSet oathkeeperv1alpha1.RuleSet
+		if err := yaml.UnmarshalStrict(item, &ruleSet); err != nil {
+			return nil, err
+		}
+		return ruleSet.ToRules().Items, nil
+	case clusterRuleKind:
+		var clusterRule oathkeeperv1alpha1.ClusterRule
+		if err := yaml.UnmarshalStrict(item, &clusterRule); err != nil {
+			return nil, err
+		}
+		return []oathkeeperv1alpha1.Rule{clusterRule.ToRule()}, nil
+	default:
+		var rule oathkeeperv1alpha1.Rule
+		if err := yaml.UnmarshalStrict(item, &rule); err != nil {
+			return nil, err
+		}
+		return []oathkeeperv1alpha1.Rule{rule}, nil
+	}
+}
+
+func isLintable(apiVersion, kind string) bool {
+	if apiVersion != oathkeeperv1alpha1.GroupVersion.String() {
+		return false
+	}
+	switch kind {
+	case ruleKind, ruleSetKind, clusterRuleKind:
+		return true
+	}
+	return false
+}
+
+func isManifestFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+func loadFile(path string) ([]Document, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return split(path, content), nil
+}
+
+// split cuts a multi-document YAML stream on "---" separators, keeping track of the line each document starts at.
+func split(file string, content []byte) []Document {
+	var docs []Document
+	var buf bytes.Buffer
+	start, lineNo := 1, 0
+
+	flush := func() {
+		if len(bytes.TrimSpace(buf.Bytes())) > 0 {
+			docs = append(docs, Document{File: file, Line: start, Raw: append([]byte(nil), buf.Bytes()...)})
+		}
+		buf.Reset()
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(content)+1)
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Text()
+		if strings.HasPrefix(line, "---") && strings.TrimSpace(strings.TrimLeft(line, "-")) == "" {
+			flush()
+			start = lineNo + 1
+			continue
+		}
+		if buf.Len() == 0 && strings.TrimSpace(line) == "" {
+			start = lineNo + 1
+		}
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	flush()
+
+	return docs
+}
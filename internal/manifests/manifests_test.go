@@ -0,0 +1,68 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package manifests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplit(t *testing.T) {
+
+	t.Run("should track the starting line of every document", func(t *testing.T) {
+
+		//given
+		content := "---\na: 1\n---\n\nb: 2\nc: 3\n---\n"
+
+		//when
+		docs := split("file.yaml", []byte(content))
+
+		//then
+		require.Len(t, docs, 2)
+		assert.Equal(t, 2, docs[0].Line)
+		assert.Equal(t, "a: 1\n", string(docs[0].Raw))
+		assert.Equal(t, 5, docs[1].Line)
+	})
+}
+
+func TestDecodeRules(t *testing.T) {
+
+	//given
+	docs, err := Load([]string{"testdata"})
+	require.NoError(t, err)
+	require.Len(t, docs, 5)
+
+	//when
+	rules, errs := DecodeRules(docs)
+
+	//then
+	t.Run("should skip documents of other kinds", func(t *testing.T) {
+		require.Len(t, rules, 4)
+		assert.Equal(t, "sample-rule-1", rules[0].Name)
+		assert.Equal(t, "testdata/rules.yaml", rules[0].File)
+		assert.Equal(t, 7, rules[0].Line)
+	})
+
+	t.Run("should expand RuleSets into the Rules of their routes", func(t *testing.T) {
+		assert.Equal(t, "orders:list", rules[1].Name)
+		assert.Equal(t, "orders:health", rules[2].Name)
+		assert.Equal(t, "test-ns-1", rules[2].Namespace)
+		assert.Equal(t, "<http|https>://foo.bar/orders/health", rules[2].Spec.Match.URL)
+		assert.Equal(t, 2, rules[2].Line)
+	})
+
+	t.Run("should decode ClusterRules as Rules without a namespace", func(t *testing.T) {
+		assert.Equal(t, "health", rules[3].Name)
+		assert.Empty(t, rules[3].Namespace)
+		assert.Equal(t, 20, rules[3].Line)
+	})
+
+	t.Run("should report unknown fields", func(t *testing.T) {
+		require.Len(t, errs, 1)
+		assert.Equal(t, 19, errs[0].Line)
+		assert.Contains(t, errs[0].Error(), "authorizers")
+	})
+}
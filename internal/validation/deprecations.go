@@ -0,0 +1,102 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Kinds of handlers, as named in deprecation warnings
+const (
+	KindAuthenticator = "authenticator"
+	KindAuthorizer    = "authorizer"
+	KindMutator       = "mutator"
+	KindError         = "error"
+)
+
+// Deprecation describes a handler Oathkeeper deprecated and what replaces it
+type Deprecation struct {
+	Kind    string
+	Handler string
+	// Replacement is the handler to use instead, empty if there is none
+	Replacement string
+	// Translate converts the JSON config of the handler into the one of its replacement. It is nil when configs can't
+	// be translated, the replacement is then only suggested.
+	Translate func(config json.RawMessage) (json.RawMessage, error)
+}
+
+// Deprecations are the deprecated handlers maester knows about
+var Deprecations = []Deprecation{
+	{
+		Kind:        KindAuthorizer,
+		Handler:     "keto_engine_acp_ory",
+		Replacement: "remote_json",
+		Translate:   translateKetoEngineACPOry,
+	},
+}
+
+// DeprecationsFor returns the deprecations of the handlers the profile deprecates. Without a profile all of them apply.
+func DeprecationsFor(profile *Profile) []Deprecation {
+	var deprecations []Deprecation
+	for _, d := range Deprecations {
+		if profile == nil || profile.IsDeprecated(d.Handler) {
+			deprecations = append(deprecations, d)
+		}
+	}
+	return deprecations
+}
+
+// Deprecation returns the deprecation of the handler, or nil if it isn't deprecated
+func (c Config) Deprecation(kind, handler string) *Deprecation {
+	for i := range c.Deprecated {
+		if c.Deprecated[i].Kind == kind && c.Deprecated[i].Handler == handler {
+			return &c.Deprecated[i]
+		}
+	}
+	return nil
+}
+
+// translateKetoEngineACPOry turns the config of keto_engine_acp_ory into a remote_json config asking the same ORY Keto
+// ACP engine. The base_url has to be part of the config, and regex capture groups can't be translated.
+func translateKetoEngineACPOry(config json.RawMessage) (json.RawMessage, error) {
+	var acp struct {
+		RequiredAction   string `json:"required_action"`
+		RequiredResource string `json:"required_resource"`
+		Subject          string `json:"subject"`
+		Flavor           string `json:"flavor"`
+		BaseURL          string `json:"base_url"`
+	}
+	if len(config) != 0 {
+		if err := json.Unmarshal(config, &acp); err != nil {
+			return nil, err
+		}
+	}
+	if acp.BaseURL == "" {
+		return nil, fmt.Errorf("base_url is not part of the config")
+	}
+	if strings.Contains(acp.RequiredAction, "$") || strings.Contains(acp.RequiredResource, "$") {
+		return nil, fmt.Errorf("regex capture groups in required_action or required_resource can't be translated")
+	}
+	if acp.Subject == "" {
+		acp.Subject = "{{ print .Subject }}"
+	}
+	if acp.Flavor == "" {
+		acp.Flavor = "regex"
+	}
+
+	payload, err := json.Marshal(map[string]string{
+		"action":   acp.RequiredAction,
+		"resource": acp.RequiredResource,
+		"subject":  acp.Subject,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(map[string]string{
+		"remote":  strings.TrimSuffix(acp.BaseURL, "/") + "/engines/acp/ory/" + acp.Flavor + "/allowed",
+		"payload": string(payload),
+	})
+}
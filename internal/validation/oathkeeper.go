@@ -0,0 +1,71 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Handlers lists the handlers of each kind an Oathkeeper config enables
+type Handlers struct {
+	Authenticators []string
+	Authorizers    []string
+	Mutators       []string
+	Errors         []string
+}
+
+type handlerToggle struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// EnabledHandlers reads the handlers with enabled: true from the YAML or JSON config file of Oathkeeper. Like Oathkeeper,
+// it treats the json error handler as enabled unless it is disabled explicitly.
+func EnabledHandlers(raw []byte) (*Handlers, error) {
+	data, err := yaml.YAMLToJSON(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	var config struct {
+		Authenticators map[string]handlerToggle `json:"authenticators"`
+		Authorizers    map[string]handlerToggle `json:"authorizers"`
+		Mutators       map[string]handlerToggle `json:"mutators"`
+		Errors         struct {
+			Handlers map[string]handlerToggle `json:"handlers"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("not an Oathkeeper config: %w", err)
+	}
+
+	if _, ok := config.Errors.Handlers["json"]; !ok {
+		if config.Errors.Handlers == nil {
+			config.Errors.Handlers = map[string]handlerToggle{}
+		}
+		enabled := true
+		config.Errors.Handlers["json"] = handlerToggle{Enabled: &enabled}
+	}
+
+	return &Handlers{
+		Authenticators: enabled(config.Authenticators),
+		Authorizers:    enabled(config.Authorizers),
+		Mutators:       enabled(config.Mutators),
+		Errors:         enabled(config.Errors.Handlers),
+	}, nil
+}
+
+func enabled(handlers map[string]handlerToggle) []string {
+	names := []string{}
+	for name, h := range handlers {
+		if h.Enabled != nil && *h.Enabled {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,98 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import "fmt"
+
+// Profile describes what a release of Oathkeeper accepts, from its Since version up to the next profile
+type Profile struct {
+	Since          Version
+	Authenticators []string
+	Authorizers    []string
+	Mutators       []string
+	Errors         []string
+	// Deprecated are the handlers the release still accepts but warns about
+	Deprecated []string
+	// Fields are the fields of a rule the release reads, all others are left out when rendering
+	Fields []string
+	// GRPCMatch tells whether rules can match gRPC requests
+	GRPCMatch bool
+}
+
+var (
+	authenticatorsV034 = []string{"noop", "unauthorized", "anonymous", "cookie_session", "oauth2_client_credentials", "oauth2_introspection", "jwt"}
+	authenticatorsV038 = []string{"noop", "unauthorized", "anonymous", "cookie_session", "oauth2_client_credentials", "oauth2_introspection", "jwt", "bearer_token"}
+	authorizersV034    = []string{"allow", "deny", "keto_engine_acp_ory"}
+	authorizersV037    = []string{"allow", "deny", "keto_engine_acp_ory", "remote", "remote_json"}
+	mutatorsV034       = []string{"noop", "id_token", "header", "cookie", "hydrator"}
+	errorsV035         = []string{"json", "redirect", "www_authenticate"}
+	fieldsV034         = []string{"id", "upstream", "match", "authenticators", "authorizer", "mutators"}
+	fieldsV035         = []string{"id", "upstream", "match", "authenticators", "authorizer", "mutators", "errors"}
+)
+
+// Profiles are the known releases of Oathkeeper, oldest first
+var Profiles = []Profile{
+	{
+		Since:          Version{Minor: 34},
+		Authenticators: authenticatorsV034,
+		Authorizers:    authorizersV034,
+		Mutators:       mutatorsV034,
+		Fields:         fieldsV034,
+	},
+	{
+		Since:          Version{Minor: 35},
+		Authenticators: authenticatorsV034,
+		Authorizers:    authorizersV034,
+		Mutators:       mutatorsV034,
+		Errors:         errorsV035,
+		Fields:         fieldsV035,
+	},
+	{
+		Since:          Version{Minor: 37},
+		Authenticators: authenticatorsV034,
+		Authorizers:    authorizersV037,
+		Mutators:       mutatorsV034,
+		Errors:         errorsV035,
+		Fields:         fieldsV035,
+	},
+	{
+		Since:          Version{Minor: 38},
+		Authenticators: authenticatorsV038,
+		Authorizers:    authorizersV037,
+		Mutators:       mutatorsV034,
+		Errors:         errorsV035,
+		Deprecated:     []string{"keto_engine_acp_ory"},
+		Fields:         fieldsV035,
+	},
+	{
+		Since:          GRPCMatchVersion,
+		Authenticators: authenticatorsV038,
+		Authorizers:    authorizersV037,
+		Mutators:       mutatorsV034,
+		Errors:         errorsV035,
+		Deprecated:     []string{"keto_engine_acp_ory"},
+		Fields:         fieldsV035,
+		GRPCMatch:      true,
+	},
+}
+
+// ProfileFor returns the profile of the given release of Oathkeeper, the newest one it is at least
+func ProfileFor(version Version) (*Profile, error) {
+	for i := len(Profiles) - 1; i >= 0; i-- {
+		if version.AtLeast(Profiles[i].Since) {
+			return &Profiles[i], nil
+		}
+	}
+	return nil, fmt.Errorf("Oathkeeper %s is not supported, the oldest supported version is %s", version, Profiles[0].Since)
+}
+
+// IsDeprecated tells whether the release warns about the handler. A nil Profile deprecates nothing.
+func (p *Profile) IsDeprecated(handler string) bool {
+	return p != nil && isValid(handler, p.Deprecated)
+}
+
+// HasField tells whether the release reads the field of a rule. Without a Profile every field is read.
+func (p *Profile) HasField(field string) bool {
+	return p == nil || isValid(field, p.Fields)
+}
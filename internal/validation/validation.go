@@ -8,6 +8,13 @@ type Config struct {
 	AuthorizersAvailable    []string
 	MutatorsAvailable       []string
 	ErrorsAvailable         []string
+	// Profile is what the Oathkeeper the rules are rendered for accepts. Features of newer versions are rejected
+	// when it is older, or unknown.
+	Profile *Profile
+	// Deprecated are the deprecated handlers Rules get warnings about
+	Deprecated []Deprecation
+	// RewriteDeprecated renders the replacement of a deprecated handler instead, where its config can be translated
+	RewriteDeprecated bool
 }
 
 func (c Config) IsAuthenticatorValid(authenticator string) bool {
@@ -23,6 +30,11 @@ func (c Config) IsErrorValid(err string) bool {
 	return isValid(err, c.ErrorsAvailable)
 }
 
+// SupportsGRPCMatch tells whether the target Oathkeeper matches gRPC requests
+func (c Config) SupportsGRPCMatch() bool {
+	return c.Profile != nil && c.Profile.GRPCMatch
+}
+
 func isValid(current string, available []string) bool {
 	for _, a := range available {
 		if current == a {
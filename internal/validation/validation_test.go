@@ -29,3 +29,174 @@ func TestIsValid(t *testing.T) {
 		}
 	})
 }
+
+func TestParseVersion(t *testing.T) {
+	tests := map[string]struct {
+		version  string
+		expected Version
+		valid    bool
+	}{
+		"with prefix":         {"v0.40.1", Version{Minor: 40, Patch: 1}, true},
+		"without prefix":      {"0.38.25", Version{Minor: 38, Patch: 25}, true},
+		"without patch":       {"v1.2", Version{Major: 1, Minor: 2}, true},
+		"with pre-release":    {"v0.40.0-beta.1", Version{Minor: 40}, true},
+		"with a single part":  {"v1", Version{}, false},
+		"with a non-number":   {"v0.x.1", Version{}, false},
+		"with too many parts": {"0.1.2.3", Version{}, false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			version, err := ParseVersion(test.version)
+			if !test.valid {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, version)
+		})
+	}
+}
+
+func TestProfileFor(t *testing.T) {
+
+	t.Run("should pick the newest profile the version is at least", func(t *testing.T) {
+		tests := map[string]struct {
+			version Version
+			since   Version
+		}{
+			"first release of a profile": {Version{Minor: 35}, Version{Minor: 35}},
+			"patch release":              {Version{Minor: 38, Patch: 3}, Version{Minor: 38}},
+			"release without changes":    {Version{Minor: 39, Patch: 2}, Version{Minor: 38}},
+			"future release":             {Version{Major: 1}, GRPCMatchVersion},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				profile, err := ProfileFor(test.version)
+				assert.NoError(t, err)
+				assert.Equal(t, test.since, profile.Since)
+			})
+		}
+	})
+
+	t.Run("should reject versions older than every profile", func(t *testing.T) {
+		_, err := ProfileFor(Version{Minor: 33, Patch: 9})
+		assert.EqualError(t, err, "Oathkeeper v0.33.9 is not supported, the oldest supported version is v0.34.0")
+	})
+
+	t.Run("should describe what a release accepts", func(t *testing.T) {
+		v34, _ := ProfileFor(Version{Minor: 34})
+		v38, _ := ProfileFor(Version{Minor: 38})
+		v40, _ := ProfileFor(Version{Minor: 40})
+
+		assert.False(t, v34.HasField("errors"))
+		assert.True(t, v38.HasField("errors"))
+		assert.True(t, (*Profile)(nil).HasField("errors"))
+		assert.NotContains(t, v34.Authenticators, "bearer_token")
+		assert.Contains(t, v38.Authenticators, "bearer_token")
+		assert.False(t, v34.IsDeprecated("keto_engine_acp_ory"))
+		assert.True(t, v38.IsDeprecated("keto_engine_acp_ory"))
+		assert.False(t, Config{}.SupportsGRPCMatch())
+		assert.False(t, Config{Profile: v38}.SupportsGRPCMatch())
+		assert.True(t, Config{Profile: v40}.SupportsGRPCMatch())
+	})
+}
+
+func TestDeprecations(t *testing.T) {
+
+	t.Run("should apply the deprecations of the profile", func(t *testing.T) {
+		v34, _ := ProfileFor(Version{Minor: 34})
+		v38, _ := ProfileFor(Version{Minor: 38})
+
+		assert.Empty(t, DeprecationsFor(v34))
+		assert.Len(t, DeprecationsFor(v38), 1)
+		assert.Len(t, DeprecationsFor(nil), len(Deprecations))
+		assert.NotNil(t, Config{Deprecated: Deprecations}.Deprecation(KindAuthorizer, "keto_engine_acp_ory"))
+		assert.Nil(t, Config{Deprecated: Deprecations}.Deprecation(KindAuthenticator, "keto_engine_acp_ory"))
+	})
+
+	t.Run("should translate keto_engine_acp_ory into remote_json", func(t *testing.T) {
+		tests := map[string]struct {
+			config   string
+			expected string
+			err      string
+		}{
+			"with defaults": {
+				config:   `{"base_url": "http://keto/", "required_action": "get", "required_resource": "blog"}`,
+				expected: `{"payload":"{\"action\":\"get\",\"resource\":\"blog\",\"subject\":\"{{ print .Subject }}\"}","remote":"http://keto/engines/acp/ory/regex/allowed"}`,
+			},
+			"with flavor and subject": {
+				config:   `{"base_url": "http://keto", "required_action": "get", "required_resource": "blog", "subject": "{{ print .Extra.user }}", "flavor": "exact"}`,
+				expected: `{"payload":"{\"action\":\"get\",\"resource\":\"blog\",\"subject\":\"{{ print .Extra.user }}\"}","remote":"http://keto/engines/acp/ory/exact/allowed"}`,
+			},
+			"without base_url": {
+				config: `{"required_action": "get", "required_resource": "blog"}`,
+				err:    "base_url is not part of the config",
+			},
+			"with capture groups": {
+				config: `{"base_url": "http://keto", "required_action": "get", "required_resource": "blog:$1"}`,
+				err:    "regex capture groups in required_action or required_resource can't be translated",
+			},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				translated, err := translateKetoEngineACPOry([]byte(test.config))
+				if test.err != "" {
+					assert.EqualError(t, err, test.err)
+					return
+				}
+				assert.NoError(t, err)
+				assert.JSONEq(t, test.expected, string(translated))
+			})
+		}
+	})
+}
+
+func TestEnabledHandlers(t *testing.T) {
+
+	t.Run("should list the enabled handlers of each kind", func(t *testing.T) {
+		handlers, err := EnabledHandlers([]byte(`
+authenticators:
+  anonymous:
+    enabled: true
+  jwt:
+    enabled: true
+    config:
+      jwks_urls: [https://idp/jwks]
+  oauth2_introspection:
+    enabled: false
+authorizers:
+  allow:
+    enabled: true
+mutators:
+  noop: {}
+  header:
+    enabled: true
+errors:
+  handlers:
+    redirect:
+      enabled: true
+`))
+
+		assert.NoError(t, err)
+		assert.Equal(t, &Handlers{
+			Authenticators: []string{"anonymous", "jwt"},
+			Authorizers:    []string{"allow"},
+			Mutators:       []string{"header"},
+			Errors:         []string{"json", "redirect"},
+		}, handlers)
+	})
+
+	t.Run("should read JSON and keep the json error handler disabled if asked to", func(t *testing.T) {
+		handlers, err := EnabledHandlers([]byte(`{"errors": {"handlers": {"json": {"enabled": false}}}}`))
+
+		assert.NoError(t, err)
+		assert.Empty(t, handlers.Authenticators)
+		assert.Empty(t, handlers.Errors)
+	})
+
+	t.Run("should reject content that is not an Oathkeeper config", func(t *testing.T) {
+		_, err := EnabledHandlers([]byte(`authenticators: [anonymous]`))
+
+		assert.Error(t, err)
+	})
+}
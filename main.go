@@ -4,6 +4,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
@@ -15,11 +16,14 @@ import (
 	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
 	"github.com/ory/oathkeeper-maester/controllers"
 	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	// +kubebuilder:scaffold:imports
@@ -28,8 +32,12 @@ import (
 var (
 	scheme   = runtime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
+	// version is set at build time
+	version = "master"
 )
 
+const serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
 func init() {
 
 	apiv1.AddToScheme(scheme)
@@ -44,8 +52,33 @@ func main() {
 	var rulesConfigmapNamespace string
 	var rulesFileName string
 	var rulesFilePath string
+	var rulesFormat string
+	var sharding controllers.Sharding
+	var sizeLimits controllers.SizeLimits
+	var mergeMode bool
+	var adoptConfigMaps bool
+	var ruleSelector string
+	var oathkeeperClass string
+	var reportUnclaimed bool
+	var namespaceSelector string
+	var namespaced bool
+	var shardingStrategy string
+	var shardingLayout string
+	var baselineFile string
+	var baselineConfigMap string
+	var baselineKey string
+	var baselinePlacement string
+	var ruleIDTemplate string
+	var oathkeeperVersion string
+	var rewriteDeprecatedHandlers bool
+	var oathkeeperConfigFile string
+	var oathkeeperConfigMap string
+	var oathkeeperConfigKey string
+	var decisionOnlyTargets string
+	var decisionOnly bool
 
 	var operator controllers.OperatorMode
+	var configMapReconciler *controllers.ConfigMapReconciler
 
 	controllerCommand := flag.NewFlagSet("controller", flag.ExitOnError)
 	sidecarCommand := flag.NewFlagSet("sidecar", flag.ExitOnError)
@@ -53,33 +86,86 @@ func main() {
 	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "enable-leader-election", false,
 		"Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&ruleSelector, "rule-selector", "", "Label selector of the Rules this instance handles. Defaults to all Rules.")
+	flag.StringVar(&oathkeeperClass, "oathkeeper-class", "", "spec.oathkeeperClass of the Rules this instance handles. Defaults to Rules without a class.")
+	flag.StringVar(&namespaceSelector, "namespace-selector", "", "Label selector of the namespaces whose Rules this instance handles. Defaults to all namespaces.")
+	flag.BoolVar(&namespaced, "namespaced", false, "Only watch Rules and write ConfigMaps in the namespace of maester, which then only needs a Role there.")
+	flag.BoolVar(&reportUnclaimed, "report-unclaimed-rules", false, "Report Rules, RuleSets and ClusterRules that are claimed by no instance of maester.")
+	flag.StringVar(&baselineFile, "baseline-rules-file", "", "Path to a JSON or YAML file with static Oathkeeper rules rendered next to the Rules.")
+	flag.StringVar(&baselineConfigMap, "baseline-rules-configmap", "", "namespace/name of a ConfigMap with static Oathkeeper rules rendered next to the Rules.")
+	flag.StringVar(&baselineKey, "baseline-rules-key", "access-rules.json", "Key in the baseline-rules-configmap holding the rules.")
+	flag.StringVar(&baselinePlacement, "baseline-rules-placement", string(oathkeeperv1alpha1.BaselineLast), "Where to render the baseline rules: first or last.")
+	flag.StringVar(&ruleIDTemplate, "rule-id-template", "", "Go template of the IDs of Rules without spec.id, with .Name, .Namespace and .UID. Defaults to <name>.<namespace>.")
+	flag.StringVar(&oathkeeperVersion, "oathkeeper-version", "", "Version of Oathkeeper the rules are rendered for, e.g. v0.40.1. Newer features such as gRPC matches need it.")
+	flag.BoolVar(&rewriteDeprecatedHandlers, "rewrite-deprecated-handlers", false, "Render the replacement of deprecated handlers whose config can be translated, instead of only warning about them.")
+	flag.StringVar(&oathkeeperConfigFile, "oathkeeper-config-file", "", "Path to the config file of Oathkeeper, the handlers it enables are the ones available to Rules.")
+	flag.StringVar(&oathkeeperConfigMap, "oathkeeper-config-configmap", "", "namespace/name of the ConfigMap holding the config file of Oathkeeper, used like oathkeeper-config-file.")
+	flag.StringVar(&oathkeeperConfigKey, "oathkeeper-config-key", "config.yaml", "Key in the oathkeeper-config-configmap holding the config file.")
 
 	controllerCommand.StringVar(&rulesConfigmapName, "rulesConfigmapName", "oathkeeper-rules", "Name of the Configmap that stores Oathkeeper rules.")
 	controllerCommand.StringVar(&rulesConfigmapNamespace, "rulesConfigmapNamespace", "oathkeeper-maester-system", "Namespace of the Configmap that stores Oathkeeper rules.")
 	controllerCommand.StringVar(&rulesFileName, "rulesFileName", "access-rules.json", "Name of the key in ConfigMap containing the rules.json")
+	controllerCommand.StringVar(&shardingStrategy, "shardingStrategy", string(controllers.ShardingNone), "How to split the rules of a ConfigMap: none, namespace, size or hash.")
+	controllerCommand.StringVar(&shardingLayout, "shardingLayout", string(controllers.ShardingLayoutKeys), "Where to store shards: keys of the ConfigMap or separate configmaps.")
+	controllerCommand.IntVar(&sharding.MaxSize, "shardingMaxSize", controllers.DefaultShardMaxSize, "Approximate size in bytes of a shard with the size strategy.")
+	controllerCommand.IntVar(&sharding.Buckets, "shardingBuckets", controllers.DefaultShardBuckets, "Number of shards with the hash strategy.")
+	controllerCommand.BoolVar(&mergeMode, "mergeMode", false, "Keep rules in the ConfigMap that were not written by maester.")
+	controllerCommand.BoolVar(&adoptConfigMaps, "adoptConfigMaps", false, "Write into unlabelled ConfigMaps that hold nothing but the rules key, as created by older versions of maester.")
+	controllerCommand.IntVar(&sizeLimits.Warn, "rulesWarnSize", controllers.DefaultWarnConfigMapSize, "Size in bytes of a ConfigMap above which writes are reported. 0 disables the warning.")
+	controllerCommand.IntVar(&sizeLimits.Max, "rulesMaxSize", controllers.DefaultMaxConfigMapSize, "Size in bytes of a ConfigMap above which writes are refused. 0 disables the check.")
+	controllerCommand.StringVar(&rulesFormat, "rulesFormat", string(oathkeeperv1alpha1.FormatJSON), "Format of the rendered rules: json or yaml. rulesFileName has to end in .yaml or .yml for yaml.")
+
+	controllerCommand.StringVar(&decisionOnlyTargets, "decisionOnlyTargets", "", "Comma-separated namespace/name of ConfigMaps whose rules are rendered without upstream, for the decisions API only.")
 
+	sidecarCommand.BoolVar(&decisionOnly, "decisionOnly", false, "Render the rules without upstream, for the decisions API only.")
 	sidecarCommand.StringVar(&rulesFilePath, "rulesFilePath", "/etc/config/access-rules.json", "Path to the file with converted Oathkeeper rules")
+	sidecarCommand.StringVar(&rulesFormat, "rulesFormat", string(oathkeeperv1alpha1.FormatJSON), "Format of the rendered rules: json or yaml. rulesFilePath has to end in .yaml or .yml for yaml.")
 
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
 
+	switch flag.Arg(0) {
+	case "validate":
+		os.Exit(runValidate(flag.Args()[1:]))
+	case "import":
+		os.Exit(runImport(flag.Args()[1:]))
+	}
+
 	sideCarMode, err := selectMode(flag.Args(), controllerCommand, sidecarCommand)
 	if err != nil {
 		setupLog.Error(err, "problem parsing flags")
 		os.Exit(1)
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	watched := watchedNamespaces(os.Getenv("NAMESPACE"))
+	ownNamespace := ""
+	if namespaced {
+		ownNamespace, err = namespaceOfMaester(os.Getenv("NAMESPACE"))
+		if err == nil {
+			err = scopeToNamespace(ownNamespace, controllerCommand, &rulesConfigmapNamespace, namespaceSelector)
+		}
+		if err != nil {
+			setupLog.Error(err, "unable to run namespaced")
+			os.Exit(1)
+		}
+		watched = map[string]cache.Config{ownNamespace: {}}
+	}
+
+	cfg := ctrl.GetConfigOrDie()
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
 		Scheme: scheme,
 		Metrics: server.Options{
 			BindAddress: metricsAddr,
 		},
-		LeaderElection: enableLeaderElection,
+		LeaderElection:          enableLeaderElection,
+		LeaderElectionNamespace: ownNamespace,
 		// Defaults to watching all namespaces
 		Cache: cache.Options{
-			DefaultNamespaces: map[string]cache.Config{
-				os.Getenv("NAMESPACE"): {},
+			DefaultNamespaces: watched,
+			// only the ConfigMaps maester manages are cached, all others are read through the API server
+			ByObject: map[client.Object]cache.ByObject{
+				&apiv1.ConfigMap{}: {Label: labels.SelectorFromSet(labels.Set{controllers.ManagedByLabel: controllers.ManagedByValue})},
 			},
 		},
 	})
@@ -88,27 +174,133 @@ func main() {
 		os.Exit(1)
 	}
 
+	if namespaced {
+		clientset, err := kubernetes.NewForConfig(cfg)
+		if err == nil {
+			err = controllers.CheckPermissions(context.Background(), clientset.AuthorizationV1().SelfSubjectAccessReviews(), ownNamespace,
+				controllers.RequiredPermissions(sideCarMode, enableLeaderElection))
+		}
+		if err != nil {
+			setupLog.Error(err, "insufficient permissions to run namespaced")
+			os.Exit(1)
+		}
+	}
+
 	if err := validateRulesFileName(rulesFileName); err != nil {
 		setupLog.Error(err, "Validation error")
 		os.Exit(1)
 	}
+	format, err := newOutputFormat(rulesFormat, sideCarMode, rulesFileName, rulesFilePath)
+	if err != nil {
+		setupLog.Error(err, "Validation error")
+		os.Exit(1)
+	}
+
+	sharding.Strategy = controllers.ShardingStrategy(shardingStrategy)
+	sharding.Layout = controllers.ShardingLayout(shardingLayout)
+	if err := sharding.Validate(); err != nil {
+		setupLog.Error(err, "Validation error")
+		os.Exit(1)
+	}
+	if mergeMode && sharding.Enabled() {
+		setupLog.Error(fmt.Errorf("mergeMode can't be combined with shardingStrategy %s", sharding.Strategy), "Validation error")
+		os.Exit(1)
+	}
+
+	enabledHandlers, err := loadEnabledHandlers(context.Background(), mgr.GetAPIReader(), oathkeeperConfigFile, oathkeeperConfigMap, oathkeeperConfigKey)
+	if err != nil {
+		setupLog.Error(err, "unable to load the Oathkeeper config")
+		os.Exit(1)
+	}
+
+	validationConfig, err := initValidationConfig(oathkeeperVersion, rewriteDeprecatedHandlers, enabledHandlers)
+	if err != nil {
+		setupLog.Error(err, "problem parsing flags")
+		os.Exit(1)
+	}
+
+	baseline, err := loadBaseline(context.Background(), mgr.GetAPIReader(), baselineFile, baselineConfigMap, baselineKey, oathkeeperv1alpha1.BaselinePlacement(baselinePlacement))
+	if err == nil && baseline != nil {
+		err = baseline.ValidateWith(validationConfig)
+	}
+	if err != nil {
+		setupLog.Error(err, "unable to load baseline rules")
+		os.Exit(1)
+	}
+
+	claim, err := newClaim(ruleSelector, oathkeeperClass, namespaceSelector)
+	if err != nil {
+		setupLog.Error(err, "Validation error")
+		os.Exit(1)
+	}
+
+	idScheme, err := controllers.ParseRuleIDScheme(ruleIDTemplate)
+	if err != nil {
+		setupLog.Error(err, "invalid rule-id-template")
+		os.Exit(1)
+	}
 
-	validationConfig := initValidationConfig()
+	defaultTarget := types.NamespacedName{Name: rulesConfigmapName, Namespace: rulesConfigmapNamespace}
+	decisionOnlyTargetList, err := controllers.ParseDecisionOnlyTargets(decisionOnlyTargets, defaultTarget)
+	if err != nil {
+		setupLog.Error(err, "invalid decisionOnlyTargets")
+		os.Exit(1)
+	}
+	if sideCarMode && decisionOnly {
+		decisionOnlyTargetList = &controllers.DecisionOnlyTargets{All: true}
+	}
+	if decisionOnlyTargetList != nil {
+		if _, err := baseline.DecisionOnly(); err != nil {
+			setupLog.Error(err, "unable to render baseline rules into decision-only targets")
+			os.Exit(1)
+		}
+	}
+
+	defaulter := &controllers.RuleDefaulter{
+		Reader:       mgr.GetClient(),
+		Cluster:      !namespaced,
+		DecisionOnly: decisionOnlyTargetList,
+	}
+	presets := &controllers.PresetResolver{
+		Reader:  mgr.GetClient(),
+		Cluster: !namespaced,
+	}
 
 	if sideCarMode {
 		operator = &controllers.FilesOperator{
-			Log:           ctrl.Log.WithName("controllers").WithName("Rule"),
-			RulesFilePath: rulesFilePath,
+			Log:              ctrl.Log.WithName("controllers").WithName("Rule"),
+			RulesFilePath:    rulesFilePath,
+			Baseline:         baseline,
+			DecisionOnly:     decisionOnlyTargetList,
+			ValidationConfig: validationConfig,
+			Format:           format,
 		}
 	} else {
-		operator = &controllers.ConfigMapOperator{
-			Client: mgr.GetClient(),
-			Log:    ctrl.Log.WithName("controllers").WithName("Rule"),
-			DefaultConfigMap: types.NamespacedName{
-				Name:      rulesConfigmapName,
-				Namespace: rulesConfigmapNamespace,
-			},
-			RulesFileName: rulesFileName,
+		configMapOperator := &controllers.ConfigMapOperator{
+			Client:           mgr.GetClient(),
+			APIReader:        mgr.GetAPIReader(),
+			Log:              ctrl.Log.WithName("controllers").WithName("Rule"),
+			DefaultConfigMap: defaultTarget,
+			RulesFileName:    rulesFileName,
+			Version:          version,
+			Sharding:         sharding,
+			SizeLimits:       sizeLimits,
+			Merge:            mergeMode,
+			AdoptConfigMaps:  adoptConfigMaps,
+			Recorder:         mgr.GetEventRecorder("oathkeeper-maester"),
+			Baseline:         baseline,
+			DecisionOnly:     decisionOnlyTargetList,
+			ValidationConfig: validationConfig,
+			Format:           format,
+		}
+		operator = configMapOperator
+		configMapReconciler = &controllers.ConfigMapReconciler{
+			ConfigMapOperator: configMapOperator,
+			Claim:             claim,
+			Defaulter:         defaulter,
+			Presets:           presets,
+			ClusterRules:      !namespaced,
+			IDScheme:          idScheme,
 		}
 	}
 
@@ -116,6 +308,12 @@ func main() {
 		Client:           mgr.GetClient(),
 		Log:              ctrl.Log.WithName("controllers").WithName("Rule"),
 		ValidationConfig: validationConfig,
+		Claim:            claim,
+		Baseline:         baseline,
+		Defaulter:        defaulter,
+		Presets:          presets,
+		ClusterRules:     !namespaced,
+		IDScheme:         idScheme,
 		OperatorMode:     operator,
 	}
 
@@ -123,6 +321,44 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "Rule")
 		os.Exit(1)
 	}
+
+	if err := (&controllers.RuleSetReconciler{RuleReconciler: ruleReconciler}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "RuleSet")
+		os.Exit(1)
+	}
+	if ruleReconciler.ClusterRules {
+		if err := (&controllers.ClusterRuleReconciler{RuleReconciler: ruleReconciler}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ClusterRule")
+			os.Exit(1)
+		}
+	}
+
+	if configMapReconciler != nil {
+		if err := configMapReconciler.SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ConfigMap")
+			os.Exit(1)
+		}
+	}
+
+	if reportUnclaimed {
+		kinds := []string{controllers.KindRule, controllers.KindRuleSet}
+		if ruleReconciler.ClusterRules {
+			kinds = append(kinds, controllers.KindClusterRule)
+		}
+		for _, kind := range kinds {
+			unclaimedRuleReconciler := &controllers.UnclaimedRuleReconciler{
+				Client:      mgr.GetClient(),
+				Log:         ctrl.Log.WithName("controllers").WithName("Unclaimed" + kind),
+				Recorder:    mgr.GetEventRecorder("oathkeeper-maester"),
+				GracePeriod: controllers.DefaultUnclaimedGracePeriod,
+				Kind:        kind,
+			}
+			if err := unclaimedRuleReconciler.SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "Unclaimed"+kind)
+				os.Exit(1)
+			}
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	setupLog.Info("starting manager")
@@ -156,17 +392,57 @@ func removeEmptyStrings(list []string) []string {
 	return result
 }
 
-func initValidationConfig() validation.Config {
-	authenticatorsAvailable := os.Getenv(oathkeeperv1alpha1.AuthenticatorsAvailableEnv)
-	authorizersAvailable := os.Getenv(oathkeeperv1alpha1.AuthorizersAvailableEnv)
-	mutatorsAvailable := os.Getenv(oathkeeperv1alpha1.MutatorsAvailableEnv)
-	errorsAvailable := os.Getenv(oathkeeperv1alpha1.ErrorsAvailableEnv)
-	return validation.Config{
-		AuthenticatorsAvailable: parseListOrDefault(authenticatorsAvailable, oathkeeperv1alpha1.DefaultAuthenticatorsAvailable[:], oathkeeperv1alpha1.AuthenticatorsAvailableEnv),
-		AuthorizersAvailable:    parseListOrDefault(authorizersAvailable, oathkeeperv1alpha1.DefaultAuthorizersAvailable[:], oathkeeperv1alpha1.AuthorizersAvailableEnv),
-		MutatorsAvailable:       parseListOrDefault(mutatorsAvailable, oathkeeperv1alpha1.DefaultMutatorsAvailable[:], oathkeeperv1alpha1.MutatorsAvailableEnv),
-		ErrorsAvailable:         parseListOrDefault(errorsAvailable, oathkeeperv1alpha1.DefaultErrorsAvailable[:], oathkeeperv1alpha1.ErrorsAvailableEnv),
+func initValidationConfig(oathkeeperVersion string, rewriteDeprecatedHandlers bool, enabledHandlers *validation.Handlers) (validation.Config, error) {
+	return newValidationConfig(
+		os.Getenv(oathkeeperv1alpha1.AuthenticatorsAvailableEnv),
+		os.Getenv(oathkeeperv1alpha1.AuthorizersAvailableEnv),
+		os.Getenv(oathkeeperv1alpha1.MutatorsAvailableEnv),
+		os.Getenv(oathkeeperv1alpha1.ErrorsAvailableEnv),
+		oathkeeperVersion,
+		rewriteDeprecatedHandlers,
+		enabledHandlers,
+	)
+}
+
+// newValidationConfig builds the validation configuration. The lists of available handlers default to the handlers
+// enabled in the Oathkeeper config, to the ones of the profile of the Oathkeeper version, or to the Default lists of the
+// API when neither is known.
+func newValidationConfig(authenticatorsAvailable, authorizersAvailable, mutatorsAvailable, errorsAvailable, oathkeeperVersion string, rewriteDeprecatedHandlers bool, enabledHandlers *validation.Handlers) (validation.Config, error) {
+	config := validation.Config{RewriteDeprecated: rewriteDeprecatedHandlers}
+	defaultAuthenticators := oathkeeperv1alpha1.DefaultAuthenticatorsAvailable[:]
+	defaultAuthorizers := oathkeeperv1alpha1.DefaultAuthorizersAvailable[:]
+	defaultMutators := oathkeeperv1alpha1.DefaultMutatorsAvailable[:]
+	defaultErrors := oathkeeperv1alpha1.DefaultErrorsAvailable[:]
+	if oathkeeperVersion != "" {
+		version, err := validation.ParseVersion(oathkeeperVersion)
+		if err != nil {
+			return config, fmt.Errorf("oathkeeper-version: %w", err)
+		}
+		profile, err := validation.ProfileFor(version)
+		if err != nil {
+			return config, fmt.Errorf("oathkeeper-version: %w", err)
+		}
+		setupLog.Info(fmt.Sprintf("using the profile of Oathkeeper %s", profile.Since))
+		config.Profile = profile
+		defaultAuthenticators = profile.Authenticators
+		defaultAuthorizers = profile.Authorizers
+		defaultMutators = profile.Mutators
+		defaultErrors = profile.Errors
 	}
+	if enabledHandlers != nil {
+		setupLog.Info("using the handlers enabled in the Oathkeeper config")
+		defaultAuthenticators = enabledHandlers.Authenticators
+		defaultAuthorizers = enabledHandlers.Authorizers
+		defaultMutators = enabledHandlers.Mutators
+		defaultErrors = enabledHandlers.Errors
+	}
+
+	config.AuthenticatorsAvailable = parseListOrDefault(authenticatorsAvailable, defaultAuthenticators, oathkeeperv1alpha1.AuthenticatorsAvailableEnv)
+	config.AuthorizersAvailable = parseListOrDefault(authorizersAvailable, defaultAuthorizers, oathkeeperv1alpha1.AuthorizersAvailableEnv)
+	config.MutatorsAvailable = parseListOrDefault(mutatorsAvailable, defaultMutators, oathkeeperv1alpha1.MutatorsAvailableEnv)
+	config.ErrorsAvailable = parseListOrDefault(errorsAvailable, defaultErrors, oathkeeperv1alpha1.ErrorsAvailableEnv)
+	config.Deprecated = validation.DeprecationsFor(config.Profile)
+	return config, nil
 }
 
 func validateRulesFileName(rfn string) error {
@@ -177,6 +453,140 @@ func validateRulesFileName(rfn string) error {
 	return fmt.Errorf("rulesFileName: %s is not a valid name", rfn)
 }
 
+// newOutputFormat parses the format of the rendered rules and checks it against the extension of the ConfigMap key or
+// file they are written to
+func newOutputFormat(name string, sideCarMode bool, rulesFileName, rulesFilePath string) (oathkeeperv1alpha1.OutputFormat, error) {
+	format, err := oathkeeperv1alpha1.ParseOutputFormat(name)
+	if err != nil {
+		return format, fmt.Errorf("rulesFormat: %w", err)
+	}
+	if sideCarMode {
+		if err := format.CheckFileName(rulesFilePath); err != nil {
+			return format, fmt.Errorf("rulesFilePath: %w", err)
+		}
+		return format, nil
+	}
+	if err := format.CheckFileName(rulesFileName); err != nil {
+		return format, fmt.Errorf("rulesFileName: %w", err)
+	}
+	return format, nil
+}
+
+func newClaim(selector, class, namespaceSelector string) (controllers.Claim, error) {
+	claim := controllers.Claim{Class: class}
+	if selector != "" {
+		parsed, err := labels.Parse(selector)
+		if err != nil {
+			return claim, fmt.Errorf("rule-selector: %w", err)
+		}
+		claim.Selector = parsed
+	}
+	if namespaceSelector != "" {
+		parsed, err := labels.Parse(namespaceSelector)
+		if err != nil {
+			return claim, fmt.Errorf("namespace-selector: %w", err)
+		}
+		claim.NamespaceSelector = parsed
+	}
+	return claim, nil
+}
+
+// watchedNamespaces turns a comma-separated list of namespaces into the namespaces of the cache, an empty list watches all of them
+func watchedNamespaces(namespaces string) map[string]cache.Config {
+	watched := map[string]cache.Config{}
+	for _, ns := range strings.Split(namespaces, ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			watched[ns] = cache.Config{}
+		}
+	}
+	if len(watched) == 0 {
+		watched[cache.AllNamespaces] = cache.Config{}
+	}
+	return watched
+}
+
+// namespaceOfMaester returns the namespace a namespaced maester runs in, either the single namespace in NAMESPACE or the one of its service account
+func namespaceOfMaester(namespaceEnv string) (string, error) {
+	namespaces := watchedNamespaces(namespaceEnv)
+	if _, all := namespaces[cache.AllNamespaces]; !all {
+		if len(namespaces) != 1 {
+			return "", fmt.Errorf("NAMESPACE must hold a single namespace in namespaced mode, got %q", namespaceEnv)
+		}
+		for ns := range namespaces {
+			return ns, nil
+		}
+	}
+
+	ns, err := os.ReadFile(serviceAccountNamespaceFile)
+	if err != nil {
+		return "", fmt.Errorf("NAMESPACE is not set and the namespace of the service account can't be read: %w", err)
+	}
+	return strings.TrimSpace(string(ns)), nil
+}
+
+// scopeToNamespace checks the flags for access outside of the namespace and defaults the rules ConfigMap into it
+func scopeToNamespace(namespace string, controllerCommand *flag.FlagSet, rulesConfigmapNamespace *string, namespaceSelector string) error {
+	if namespaceSelector != "" {
+		return fmt.Errorf("namespace-selector needs access to all namespaces and can't be used in namespaced mode")
+	}
+
+	configmapNamespaceSet := false
+	controllerCommand.Visit(func(f *flag.Flag) {
+		configmapNamespaceSet = configmapNamespaceSet || f.Name == "rulesConfigmapNamespace"
+	})
+	if !configmapNamespaceSet {
+		*rulesConfigmapNamespace = namespace
+	} else if *rulesConfigmapNamespace != namespace {
+		return fmt.Errorf("rulesConfigmapNamespace must be %s in namespaced mode, got %s", namespace, *rulesConfigmapNamespace)
+	}
+	return nil
+}
+
+// loadBaseline reads the baseline rules from a file or a key of a ConfigMap given as namespace/name, there is no baseline if neither is set
+func loadBaseline(ctx context.Context, reader client.Reader, file, configMap, key string, placement oathkeeperv1alpha1.BaselinePlacement) (*oathkeeperv1alpha1.Baseline, error) {
+	raw, err := readFileOrConfigMap(ctx, reader, "baseline-rules", file, configMap, key)
+	if err != nil || raw == nil {
+		return nil, err
+	}
+	return oathkeeperv1alpha1.ParseBaseline(raw, placement)
+}
+
+// loadEnabledHandlers reads the handlers enabled in the config file of Oathkeeper, nil if no config is given
+func loadEnabledHandlers(ctx context.Context, reader client.Reader, file, configMap, key string) (*validation.Handlers, error) {
+	raw, err := readFileOrConfigMap(ctx, reader, "oathkeeper-config", file, configMap, key)
+	if err != nil || raw == nil {
+		return nil, err
+	}
+	return validation.EnabledHandlers(raw)
+}
+
+// readFileOrConfigMap reads the content configured by the <prefix>-file or <prefix>-configmap flags, which can't be
+// used together. Without either of them the content is nil.
+func readFileOrConfigMap(ctx context.Context, reader client.Reader, prefix, file, configMap, key string) ([]byte, error) {
+	switch {
+	case file != "" && configMap != "":
+		return nil, fmt.Errorf("%s-file and %s-configmap can't be used together", prefix, prefix)
+	case file != "":
+		return os.ReadFile(file)
+	case configMap != "":
+		namespace, name, ok := strings.Cut(configMap, "/")
+		if !ok || namespace == "" || name == "" {
+			return nil, fmt.Errorf("%s-configmap must be namespace/name, got %q", prefix, configMap)
+		}
+		var cm apiv1.ConfigMap
+		if err := reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &cm); err != nil {
+			return nil, err
+		}
+		content, ok := cm.Data[key]
+		if !ok {
+			return nil, fmt.Errorf("ConfigMap %s has no key %s", configMap, key)
+		}
+		return []byte(content), nil
+	default:
+		return nil, nil
+	}
+}
+
 func selectMode(args []string, controllerCommand *flag.FlagSet, sidecarCommand *flag.FlagSet) (bool, error) {
 	if len(args) < 1 {
 		setupLog.Info("running in controller mode")
@@ -197,6 +607,6 @@ func selectMode(args []string, controllerCommand *flag.FlagSet, sidecarCommand *
 		setupLog.Info("running in sidecar mode")
 		return true, nil
 	default:
-		return false, fmt.Errorf(`modes "controller" and "sidecar" are supported but got: %s`, args[0])
+		return false, fmt.Errorf(`modes "controller", "sidecar", "validate" and "import" are supported but got: %s`, args[0])
 	}
 }
@@ -0,0 +1,95 @@
+// Copyright © 2026 Ory Corp
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	oathkeeperv1alpha1 "github.com/ory/oathkeeper-maester/api/v1alpha1"
+	"github.com/ory/oathkeeper-maester/internal/lint"
+	"github.com/ory/oathkeeper-maester/internal/manifests"
+	"github.com/ory/oathkeeper-maester/internal/validation"
+)
+
+const (
+	formatJSON   = "json"
+	formatGitHub = "github"
+)
+
+// runValidate lints Rule, RuleSet and ClusterRule manifests on disk without talking to a cluster and returns the
+// process exit code.
+func runValidate(args []string) int {
+	var format string
+	var authenticatorsAvailable, authorizersAvailable, mutatorsAvailable, errorsAvailable string
+	var oathkeeperVersion string
+	var rewriteDeprecatedHandlers bool
+	var oathkeeperConfigFile string
+
+	validateCommand := flag.NewFlagSet("validate", flag.ExitOnError)
+	validateCommand.StringVar(&format, "format", formatJSON, fmt.Sprintf("Output format of the report, either %q or %q.", formatJSON, formatGitHub))
+	validateCommand.StringVar(&authenticatorsAvailable, oathkeeperv1alpha1.AuthenticatorsAvailableEnv, os.Getenv(oathkeeperv1alpha1.AuthenticatorsAvailableEnv), "Comma-separated list of allowed authenticators.")
+	validateCommand.StringVar(&authorizersAvailable, oathkeeperv1alpha1.AuthorizersAvailableEnv, os.Getenv(oathkeeperv1alpha1.AuthorizersAvailableEnv), "Comma-separated list of allowed authorizers.")
+	validateCommand.StringVar(&mutatorsAvailable, oathkeeperv1alpha1.MutatorsAvailableEnv, os.Getenv(oathkeeperv1alpha1.MutatorsAvailableEnv), "Comma-separated list of allowed mutators.")
+	validateCommand.StringVar(&errorsAvailable, oathkeeperv1alpha1.ErrorsAvailableEnv, os.Getenv(oathkeeperv1alpha1.ErrorsAvailableEnv), "Comma-separated list of allowed error handlers.")
+	validateCommand.StringVar(&oathkeeperVersion, "oathkeeper-version", "", "Version of Oathkeeper the rules are rendered for, e.g. v0.40.1.")
+	validateCommand.BoolVar(&rewriteDeprecatedHandlers, "rewrite-deprecated-handlers", false, "Check the replacements of deprecated handlers that are rendered instead.")
+	validateCommand.StringVar(&oathkeeperConfigFile, "oathkeeper-config-file", "", "Path to the config file of Oathkeeper, the handlers it enables are the ones allowed.")
+	if err := validateCommand.Parse(args); err != nil {
+		setupLog.Error(err, "problem parsing flags")
+		return 2
+	}
+
+	if format != formatJSON && format != formatGitHub {
+		setupLog.Error(fmt.Errorf("unsupported format: %s", format), "problem parsing flags")
+		return 2
+	}
+
+	if validateCommand.NArg() == 0 {
+		setupLog.Error(fmt.Errorf("no files or directories given"), "nothing to validate")
+		return 2
+	}
+
+	docs, err := manifests.Load(validateCommand.Args())
+	if err != nil {
+		setupLog.Error(err, "unable to read manifests")
+		return 2
+	}
+
+	var enabledHandlers *validation.Handlers
+	if oathkeeperConfigFile != "" {
+		raw, err := os.ReadFile(oathkeeperConfigFile)
+		if err == nil {
+			enabledHandlers, err = validation.EnabledHandlers(raw)
+		}
+		if err != nil {
+			setupLog.Error(err, "unable to load the Oathkeeper config")
+			return 2
+		}
+	}
+
+	validationConfig, err := newValidationConfig(authenticatorsAvailable, authorizersAvailable, mutatorsAvailable, errorsAvailable, oathkeeperVersion, rewriteDeprecatedHandlers, enabledHandlers)
+	if err != nil {
+		setupLog.Error(err, "problem parsing flags")
+		return 2
+	}
+	rules, decodeErrs := manifests.DecodeRules(docs)
+	report := lint.Run(rules, decodeErrs, validationConfig)
+
+	if format == formatGitHub {
+		err = report.WriteGitHub(os.Stdout)
+	} else {
+		err = report.WriteJSON(os.Stdout)
+	}
+	if err != nil {
+		setupLog.Error(err, "unable to write report")
+		return 2
+	}
+
+	if !report.Valid {
+		return 1
+	}
+	return 0
+}